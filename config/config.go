@@ -18,6 +18,28 @@ type Config struct {
 	DbName     string `env:"DB_NAME,required"`
 
 	JWTSecret string `env:"JWT_SECRET,required"`
+
+	// Frontend URL used to build links in emails
+	FrontendURL string `env:"FRONTEND_URL"`
+
+	// Uploaded files are stored in StorageDir and served under StoragePublicURL
+	StorageDir       string `env:"STORAGE_DIR" envDefault:"./uploads"`
+	StoragePublicURL string `env:"STORAGE_PUBLIC_URL" envDefault:"/uploads"`
+
+	// Emails are dropped, with only their recipient and subject logged, when SMTP is not configured
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     string `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	SMTPFrom     string `env:"SMTP_FROM" envDefault:"no-reply@seacatering.id"`
+
+	// Behind a reverse proxy the client IP, used by rate limits and login lockouts, is read from
+	// ProxyHeader, but only on requests coming from one of the TrustedProxies
+	ProxyHeader    string   `env:"PROXY_HEADER"`
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+
+	// Testimonials containing any of these words are flagged for moderation
+	BannedWords []string `env:"BANNED_WORDS" envSeparator:","`
 }
 
 var cfg Config
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/audit/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
+	"github.com/jevvonn/sea-catering-be/internal/models"
+)
+
+type AuditHandler struct {
+	auditUsecase usecase.AuditUsecaseItf
+	validator    validator.ValidationService
+}
+
+func NewAuditHandler(
+	router fiber.Router,
+	mw *middleware.Middleware,
+	auditUsecase usecase.AuditUsecaseItf,
+	validator validator.ValidationService,
+) {
+	handler := AuditHandler{auditUsecase, validator}
+
+	router.Get("/audit-logs", mw.Authenticated, mw.RequirePermission(constant.PermissionAuditRead), handler.GetAuditLogs)
+}
+
+// @Tags         Audit
+// @Summary      Get Audit Logs
+// @Accept       json
+// @Produce      json
+// @Param        action query string false "Action, e.g AUTH_ACCOUNT_LOCKED"
+// @Param        target_id query string false "Target ID"
+// @Param        limit query int false "Limit"
+// @Param        page query int false "Page"
+// @Router       /audit-logs [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetAuditLogsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *AuditHandler) GetAuditLogs(ctx *fiber.Ctx) error {
+	var req dto.GetAuditLogQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	response, err := h.auditUsecase.GetAuditLogs(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve audit logs",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Audit logs retrieved successfully",
+			Data:    response,
+		},
+	)
+}
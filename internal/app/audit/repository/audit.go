@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type AuditPostgreSQLItf interface {
+	CreateAuditLog(log entity.AuditLog) error
+	GetAuditLogs(query dto.GetAuditLogQuery) ([]entity.AuditLog, int64, error)
+}
+
+type AuditPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAuditPostgreSQL(db *gorm.DB) AuditPostgreSQLItf {
+	return &AuditPostgreSQL{db}
+}
+
+func (r *AuditPostgreSQL) CreateAuditLog(log entity.AuditLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+
+	return r.db.Create(&log).Error
+}
+
+func (r *AuditPostgreSQL) GetAuditLogs(query dto.GetAuditLogQuery) ([]entity.AuditLog, int64, error) {
+	var logs []entity.AuditLog
+	var total int64
+
+	db := r.db.Model(&entity.AuditLog{})
+	if query.Action != "" {
+		db = db.Where("action = ?", query.Action)
+	}
+
+	if query.TargetID != "" {
+		db = db.Where("target_id = ?", query.TargetID)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("created_at DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
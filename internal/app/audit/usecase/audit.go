@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+)
+
+type AuditUsecaseItf interface {
+	GetAuditLogs(ctx *fiber.Ctx, query dto.GetAuditLogQuery) (dto.GetAuditLogsResponse, error)
+}
+
+type AuditUsecase struct {
+	auditRepo repository.AuditPostgreSQLItf
+}
+
+func NewAuditUsecase(auditRepo repository.AuditPostgreSQLItf) AuditUsecaseItf {
+	return &AuditUsecase{auditRepo}
+}
+
+func (u *AuditUsecase) GetAuditLogs(ctx *fiber.Ctx, query dto.GetAuditLogQuery) (dto.GetAuditLogsResponse, error) {
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+
+	logs, total, err := u.auditRepo.GetAuditLogs(query)
+	if err != nil {
+		return dto.GetAuditLogsResponse{}, err
+	}
+
+	return dto.GetAuditLogsResponse{
+		AuditLogs: logs,
+		Total:     total,
+		Page:      query.Page,
+		Limit:     query.Limit,
+	}, nil
+}
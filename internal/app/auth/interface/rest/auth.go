@@ -1,8 +1,12 @@
 package rest
 
 import (
+	"errors"
+
 	"github.com/jevvonn/sea-catering-be/internal/app/auth/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/i18n"
 	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
 	"github.com/jevvonn/sea-catering-be/internal/middleware"
 	"github.com/jevvonn/sea-catering-be/internal/models"
@@ -17,6 +21,7 @@ type AuthHandler struct {
 
 func NewAuthHandler(
 	router fiber.Router,
+	mw *middleware.Middleware,
 	authUsecase usecase.AuthUsecaseItf,
 	validator validator.ValidationService,
 ) {
@@ -25,7 +30,8 @@ func NewAuthHandler(
 	router.Post("/auth/login", handler.Login)
 	router.Post("/auth/register", handler.Register)
 
-	router.Get("/auth/session", middleware.Authenticated, handler.Session)
+	router.Get("/auth/session", mw.Authenticated, handler.Session)
+	router.Post("/auth/unlock", mw.Authenticated, mw.RequirePermission(constant.PermissionAuthUnlock), handler.UnlockLogin)
 }
 
 // @Tags         Auth
@@ -36,19 +42,20 @@ func NewAuthHandler(
 // @Router       /auth/login [post]
 // @Success      200  {object}  dto.LoginResponse
 // @Failure      400  {object}  models.JSONResponseModel
+// @Failure      429  {object}  models.JSONResponseModel
 func (h *AuthHandler) Login(ctx *fiber.Ctx) error {
 	var req dto.LoginRequest
 	err := ctx.BodyParser(&req)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
-				Message: "Invalid Request",
-				Errors:  err.Error(),
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
 			},
 		)
 	}
 
-	err = h.validator.Validate(req)
+	err = h.validator.ValidateLocale(req, i18n.Locale(ctx))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(
 			err.(*validator.ValidationError),
@@ -57,17 +64,22 @@ func (h *AuthHandler) Login(ctx *fiber.Ctx) error {
 
 	res, err := h.authUsecase.Login(ctx, req)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, usecase.ErrTooManyLoginAttempts) {
+			status = fiber.StatusTooManyRequests
+		}
+
+		return ctx.Status(status).JSON(
 			models.JSONResponseModel{
-				Message: "Invalid Request",
-				Errors:  err.Error(),
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
 			},
 		)
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(
 		models.JSONResponseModel{
-			Message: "User Logged In Successfully",
+			Message: i18n.Message(ctx, "User Logged In Successfully"),
 			Data:    res,
 		},
 	)
@@ -87,13 +99,13 @@ func (h *AuthHandler) Register(ctx *fiber.Ctx) error {
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
-				Message: "Invalid Request",
-				Errors:  err.Error(),
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
 			},
 		)
 	}
 
-	err = h.validator.Validate(req)
+	err = h.validator.ValidateLocale(req, i18n.Locale(ctx))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(
 			err.(*validator.ValidationError),
@@ -104,15 +116,15 @@ func (h *AuthHandler) Register(ctx *fiber.Ctx) error {
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
-				Message: "Invalid Request",
-				Errors:  err.Error(),
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
 			},
 		)
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(
 		models.JSONResponseModel{
-			Message: "User Registered Successfully",
+			Message: i18n.Message(ctx, "User Registered Successfully"),
 		},
 	)
 }
@@ -143,3 +155,48 @@ func (h *AuthHandler) Session(ctx *fiber.Ctx) error {
 		},
 	)
 }
+
+// @Tags         Auth
+// @Summary      Unlock a Locked Account or IP Address
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.UnlockLoginRequest  true  "Request body"
+// @Router       /auth/unlock [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *AuthHandler) UnlockLogin(ctx *fiber.Ctx) error {
+	var req dto.UnlockLoginRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	err = h.authUsecase.UnlockLogin(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Login Unlocked Successfully",
+		},
+	)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AuthPostgreSQLItf interface {
+	GetLoginAttempt(scope string, key string) (entity.LoginAttempt, error)
+	IncrementLoginAttempt(scope string, key string, windowStart time.Time) (entity.LoginAttempt, error)
+	LockLoginAttempt(scope string, key string, lockedUntil time.Time) error
+	ResetLoginAttempt(scope string, key string) error
+}
+
+type AuthPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAuthPostgreSQL(db *gorm.DB) AuthPostgreSQLItf {
+	return &AuthPostgreSQL{db}
+}
+
+func (r *AuthPostgreSQL) GetLoginAttempt(scope string, key string) (entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+	err := r.db.Where(&entity.LoginAttempt{Scope: scope, Key: key}).First(&attempt).Error
+	return attempt, err
+}
+
+// IncrementLoginAttempt counts a failed login in a single upsert so parallel failures are all
+// counted. Failures before windowStart are forgotten unless the attempt is still locked.
+func (r *AuthPostgreSQL) IncrementLoginAttempt(scope string, key string, windowStart time.Time) (entity.LoginAttempt, error) {
+	now := time.Now()
+	attempt := entity.LoginAttempt{
+		ID:           uuid.New(),
+		Scope:        scope,
+		Key:          key,
+		FailedCount:  1,
+		LastFailedAt: &now,
+	}
+
+	err := r.db.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"failed_count": gorm.Expr(
+					`CASE WHEN login_attempts.last_failed_at < ? AND (login_attempts.locked_until IS NULL OR login_attempts.locked_until < ?)
+					THEN 1 ELSE login_attempts.failed_count + 1 END`,
+					windowStart, now,
+				),
+				"last_failed_at": now,
+				"updated_at":     now,
+			}),
+		},
+		clause.Returning{},
+	).Create(&attempt).Error
+
+	return attempt, err
+}
+
+func (r *AuthPostgreSQL) LockLoginAttempt(scope string, key string, lockedUntil time.Time) error {
+	return r.db.Model(&entity.LoginAttempt{}).
+		Where(&entity.LoginAttempt{Scope: scope, Key: key}).
+		Update("locked_until", lockedUntil).Error
+}
+
+func (r *AuthPostgreSQL) ResetLoginAttempt(scope string, key string) error {
+	return r.db.Where(&entity.LoginAttempt{Scope: scope, Key: key}).Delete(&entity.LoginAttempt{}).Error
+}
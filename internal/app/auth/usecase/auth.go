@@ -2,8 +2,14 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	authRepo "github.com/jevvonn/sea-catering-be/internal/app/auth/repository"
 	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
 	"github.com/jevvonn/sea-catering-be/internal/infra/jwt"
@@ -18,23 +24,30 @@ type AuthUsecaseItf interface {
 	Register(ctx *fiber.Ctx, req dto.RegisterRequest) error
 	Login(ctx *fiber.Ctx, req dto.LoginRequest) (dto.LoginResponse, error)
 	Session(ctx *fiber.Ctx) (dto.SessionResponse, error)
+	UnlockLogin(ctx *fiber.Ctx, req dto.UnlockLoginRequest) error
 }
 
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
 type AuthUsecase struct {
-	userRepo userRepo.UserPostgreSQLItf
+	userRepo  userRepo.UserPostgreSQLItf
+	authRepo  authRepo.AuthPostgreSQLItf
+	auditRepo auditRepo.AuditPostgreSQLItf
 }
 
 func NewAuthUsecase(
 	userRepo userRepo.UserPostgreSQLItf,
+	authRepo authRepo.AuthPostgreSQLItf,
+	auditRepo auditRepo.AuditPostgreSQLItf,
 ) AuthUsecaseItf {
-	return &AuthUsecase{userRepo}
+	return &AuthUsecase{userRepo, authRepo, auditRepo}
 }
 
 func (u *AuthUsecase) Register(ctx *fiber.Ctx, req dto.RegisterRequest) error {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
 	// Check if username already exists
-	user, err := u.userRepo.GetSpecificUser(entity.User{
-		Email: req.Email,
-	})
+	user, err := u.userRepo.GetUserByEmail(email)
 
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
@@ -54,7 +67,7 @@ func (u *AuthUsecase) Register(ctx *fiber.Ctx, req dto.RegisterRequest) error {
 	user = entity.User{
 		ID:       uuid.New(),
 		Name:     req.Name,
-		Email:    req.Email,
+		Email:    email,
 		Password: hashedPassword,
 	}
 	err = u.userRepo.CreateUser(user)
@@ -67,23 +80,55 @@ func (u *AuthUsecase) Register(ctx *fiber.Ctx, req dto.RegisterRequest) error {
 }
 
 func (u *AuthUsecase) Login(ctx *fiber.Ctx, req dto.LoginRequest) (dto.LoginResponse, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	ip := ctx.IP()
+
+	emailAttempt, err := u.getLoginAttempt(constant.LoginAttemptScopeEmail, email)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	ipAttempt, err := u.getLoginAttempt(constant.LoginAttemptScopeIP, ip)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	if err := checkLoginAttempt(emailAttempt); err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	if err := checkLoginAttempt(ipAttempt); err != nil {
+		return dto.LoginResponse{}, err
+	}
+
 	// Check if username exists
-	user, err := u.userRepo.GetSpecificUser(entity.User{
-		Email: req.Email,
-	})
+	user, err := u.userRepo.GetUserByEmail(email)
 
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return dto.LoginResponse{}, err
 	}
 
-	if user.ID == uuid.Nil {
+	// Check password
+	if user.ID == uuid.Nil || !utils.VerifyPassword(req.Password, user.Password) {
+		if err := u.recordFailedLogin(ctx, constant.LoginAttemptScopeEmail, email, constant.LoginAccountLockThreshold); err != nil {
+			return dto.LoginResponse{}, err
+		}
+
+		if err := u.recordFailedLogin(ctx, constant.LoginAttemptScopeIP, ip, constant.LoginIPLockThreshold); err != nil {
+			return dto.LoginResponse{}, err
+		}
+
 		return dto.LoginResponse{}, errors.New("email or password is incorrect")
 	}
 
-	// Check password
-	if !utils.VerifyPassword(req.Password, user.Password) {
-		return dto.LoginResponse{}, errors.New("email or password is incorrect")
+	if err := u.authRepo.ResetLoginAttempt(constant.LoginAttemptScopeEmail, email); err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	if !user.IsActive {
+		return dto.LoginResponse{}, errors.New("your account has been deactivated")
 	}
+
 	// Create Jwt token
 	token, err := jwt.CreateAuthToken(user.ID.String(), user.Email, user.Role)
 
@@ -119,3 +164,119 @@ func (u *AuthUsecase) Session(ctx *fiber.Ctx) (dto.SessionResponse, error) {
 		Role:  user.Role,
 	}, nil
 }
+
+func (u *AuthUsecase) UnlockLogin(ctx *fiber.Ctx, req dto.UnlockLoginRequest) error {
+	actorId := uuid.MustParse(ctx.Locals("userId").(string))
+
+	if req.Email != "" {
+		email := strings.ToLower(strings.TrimSpace(req.Email))
+		if err := u.authRepo.ResetLoginAttempt(constant.LoginAttemptScopeEmail, email); err != nil {
+			return err
+		}
+
+		err := u.auditRepo.CreateAuditLog(entity.AuditLog{
+			ActorID:    &actorId,
+			Action:     constant.AuditActionAccountUnlocked,
+			TargetType: constant.LoginAttemptScopeEmail,
+			TargetID:   email,
+			IPAddress:  ctx.IP(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if req.IPAddress != "" {
+		if err := u.authRepo.ResetLoginAttempt(constant.LoginAttemptScopeIP, req.IPAddress); err != nil {
+			return err
+		}
+
+		err := u.auditRepo.CreateAuditLog(entity.AuditLog{
+			ActorID:    &actorId,
+			Action:     constant.AuditActionIPUnlocked,
+			TargetType: constant.LoginAttemptScopeIP,
+			TargetID:   req.IPAddress,
+			IPAddress:  ctx.IP(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *AuthUsecase) getLoginAttempt(scope string, key string) (entity.LoginAttempt, error) {
+	attempt, err := u.authRepo.GetLoginAttempt(scope, key)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.LoginAttempt{}, err
+	}
+
+	attempt.Scope = scope
+	attempt.Key = key
+
+	// Failures outside the attempt window are forgotten
+	if attempt.LastFailedAt != nil && time.Since(*attempt.LastFailedAt) > constant.LoginAttemptWindow {
+		if attempt.LockedUntil == nil || attempt.LockedUntil.Before(time.Now()) {
+			attempt.FailedCount = 0
+			attempt.LockedUntil = nil
+		}
+	}
+
+	return attempt, nil
+}
+
+func (u *AuthUsecase) recordFailedLogin(ctx *fiber.Ctx, scope string, key string, lockThreshold int) error {
+	attempt, err := u.authRepo.IncrementLoginAttempt(scope, key, time.Now().Add(-constant.LoginAttemptWindow))
+	if err != nil {
+		return err
+	}
+
+	if attempt.FailedCount < lockThreshold {
+		return nil
+	}
+
+	lockedUntil := time.Now().Add(constant.LoginLockDuration)
+	attempt.LockedUntil = &lockedUntil
+	if err := u.authRepo.LockLoginAttempt(scope, key, lockedUntil); err != nil {
+		return err
+	}
+
+	action := constant.AuditActionAccountLocked
+	if attempt.Scope == constant.LoginAttemptScopeIP {
+		action = constant.AuditActionIPLocked
+	}
+
+	return u.auditRepo.CreateAuditLog(entity.AuditLog{
+		Action:     action,
+		TargetType: attempt.Scope,
+		TargetID:   attempt.Key,
+		IPAddress:  ctx.IP(),
+		Details:    fmt.Sprintf("locked until %s after %d failed attempts", attempt.LockedUntil.Format(time.RFC3339), attempt.FailedCount),
+	})
+}
+
+func checkLoginAttempt(attempt entity.LoginAttempt) error {
+	now := time.Now()
+
+	if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+		return fmt.Errorf("%w, try again in %s", ErrTooManyLoginAttempts, attempt.LockedUntil.Sub(now).Round(time.Second))
+	}
+
+	if attempt.FailedCount < constant.LoginDelayThreshold || attempt.LastFailedAt == nil {
+		return nil
+	}
+
+	// Every failure past the threshold doubles the wait before the next attempt
+	delay := constant.LoginBaseDelay << (attempt.FailedCount - constant.LoginDelayThreshold)
+	if delay > constant.LoginMaxDelay || delay <= 0 {
+		delay = constant.LoginMaxDelay
+	}
+
+	nextAttempt := attempt.LastFailedAt.Add(delay)
+	if nextAttempt.After(now) {
+		return fmt.Errorf("%w, try again in %s", ErrTooManyLoginAttempts, nextAttempt.Sub(now).Round(time.Second))
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	authRepo "github.com/jevvonn/sea-catering-be/internal/app/auth/repository"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
+	"github.com/valyala/fasthttp"
+	"gorm.io/gorm"
+)
+
+type fakeAuthRepo struct {
+	authRepo.AuthPostgreSQLItf
+
+	mu       sync.Mutex
+	attempts map[string]entity.LoginAttempt
+}
+
+func (r *fakeAuthRepo) GetLoginAttempt(scope string, key string) (entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[scope+":"+key]
+	if !ok {
+		return entity.LoginAttempt{}, gorm.ErrRecordNotFound
+	}
+
+	return attempt, nil
+}
+
+func (r *fakeAuthRepo) IncrementLoginAttempt(scope string, key string, windowStart time.Time) (entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	attempt := r.attempts[scope+":"+key]
+	expired := attempt.LastFailedAt != nil && attempt.LastFailedAt.Before(windowStart) &&
+		(attempt.LockedUntil == nil || attempt.LockedUntil.Before(now))
+	if expired {
+		attempt.FailedCount = 0
+	}
+
+	attempt.Scope = scope
+	attempt.Key = key
+	attempt.FailedCount++
+	attempt.LastFailedAt = &now
+	r.attempts[scope+":"+key] = attempt
+
+	return attempt, nil
+}
+
+func (r *fakeAuthRepo) LockLoginAttempt(scope string, key string, lockedUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt := r.attempts[scope+":"+key]
+	attempt.LockedUntil = &lockedUntil
+	r.attempts[scope+":"+key] = attempt
+	return nil
+}
+
+func (r *fakeAuthRepo) ResetLoginAttempt(scope string, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, scope+":"+key)
+	return nil
+}
+
+type fakeUserRepo struct {
+	userRepo.UserPostgreSQLItf
+
+	users []entity.User
+}
+
+func (r *fakeUserRepo) GetUserByEmail(email string) (entity.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+
+	return entity.User{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeUserRepo) CreateUser(user entity.User) error {
+	r.users = append(r.users, user)
+	return nil
+}
+
+type fakeAuditRepo struct {
+	auditRepo.AuditPostgreSQLItf
+
+	mu   sync.Mutex
+	logs []entity.AuditLog
+}
+
+func (r *fakeAuditRepo) CreateAuditLog(log entity.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func newTestAuthUsecase(t *testing.T, users ...entity.User) (*AuthUsecase, *fakeAuthRepo, *fakeAuditRepo) {
+	t.Helper()
+
+	auth := &fakeAuthRepo{attempts: map[string]entity.LoginAttempt{}}
+	audit := &fakeAuditRepo{}
+	return &AuthUsecase{&fakeUserRepo{users: users}, auth, audit}, auth, audit
+}
+
+func newTestCtx(t *testing.T) *fiber.Ctx {
+	t.Helper()
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	t.Cleanup(func() { app.ReleaseCtx(ctx) })
+	return ctx
+}
+
+func timeAgo(d time.Duration) *time.Time {
+	at := time.Now().Add(-d)
+	return &at
+}
+
+func TestCheckLoginAttempt(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt entity.LoginAttempt
+		blocked bool
+	}{
+		{"no failures", entity.LoginAttempt{}, false},
+		{"below delay threshold", entity.LoginAttempt{FailedCount: 2, LastFailedAt: timeAgo(0)}, false},
+		{"first delay pending", entity.LoginAttempt{FailedCount: 3, LastFailedAt: timeAgo(0)}, true},
+		{"first delay over", entity.LoginAttempt{FailedCount: 3, LastFailedAt: timeAgo(2 * time.Second)}, false},
+		{"delay doubles", entity.LoginAttempt{FailedCount: 5, LastFailedAt: timeAgo(3 * time.Second)}, true},
+		{"doubled delay over", entity.LoginAttempt{FailedCount: 5, LastFailedAt: timeAgo(5 * time.Second)}, false},
+		{"delay is capped", entity.LoginAttempt{FailedCount: 100, LastFailedAt: timeAgo(constant.LoginMaxDelay + time.Second)}, false},
+		{"locked", entity.LoginAttempt{FailedCount: 1, LockedUntil: timeAgo(-time.Minute)}, true},
+		{"lock expired", entity.LoginAttempt{FailedCount: 1, LockedUntil: timeAgo(time.Second)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLoginAttempt(tt.attempt)
+			if blocked := errors.Is(err, ErrTooManyLoginAttempts); blocked != tt.blocked {
+				t.Fatalf("blocked = %v, want %v (err %v)", blocked, tt.blocked, err)
+			}
+		})
+	}
+}
+
+func TestLoginDelaysRepeatedFailures(t *testing.T) {
+	u, auth, _ := newTestAuthUsecase(t)
+	ctx := newTestCtx(t)
+
+	for i := 0; i < constant.LoginDelayThreshold; i++ {
+		_, err := u.Login(ctx, dto.LoginRequest{Email: "nobody@example.com", Password: "wrong"})
+		if err == nil || errors.Is(err, ErrTooManyLoginAttempts) {
+			t.Fatalf("attempt %d: err = %v, want incorrect credentials", i+1, err)
+		}
+	}
+
+	_, err := u.Login(ctx, dto.LoginRequest{Email: "nobody@example.com", Password: "wrong"})
+	if !errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Fatalf("err = %v, want %v", err, ErrTooManyLoginAttempts)
+	}
+
+	// Delayed attempts are rejected before they are counted
+	attempt := auth.attempts[constant.LoginAttemptScopeEmail+":nobody@example.com"]
+	if attempt.FailedCount != constant.LoginDelayThreshold {
+		t.Fatalf("failed count = %d, want %d", attempt.FailedCount, constant.LoginDelayThreshold)
+	}
+}
+
+func TestLoginLocksAccount(t *testing.T) {
+	password, err := utils.HashPassword("secret123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := entity.User{ID: uuid.New(), Email: "jane@example.com", Password: password, IsActive: true}
+	u, auth, audit := newTestAuthUsecase(t, user)
+	ctx := newTestCtx(t)
+
+	// One failure short of the lock, with the delay already over
+	auth.attempts[constant.LoginAttemptScopeEmail+":jane@example.com"] = entity.LoginAttempt{
+		Scope:        constant.LoginAttemptScopeEmail,
+		Key:          "jane@example.com",
+		FailedCount:  constant.LoginAccountLockThreshold - 1,
+		LastFailedAt: timeAgo(constant.LoginMaxDelay),
+	}
+
+	_, err = u.Login(ctx, dto.LoginRequest{Email: "Jane@Example.com", Password: "wrong"})
+	if err == nil || errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Fatalf("err = %v, want incorrect credentials", err)
+	}
+
+	attempt := auth.attempts[constant.LoginAttemptScopeEmail+":jane@example.com"]
+	if attempt.LockedUntil == nil || !attempt.LockedUntil.After(time.Now().Add(constant.LoginLockDuration-time.Minute)) {
+		t.Fatalf("locked until = %v, want about %s from now", attempt.LockedUntil, constant.LoginLockDuration)
+	}
+
+	if len(audit.logs) != 1 || audit.logs[0].Action != constant.AuditActionAccountLocked {
+		t.Fatalf("audit logs = %+v, want one %s", audit.logs, constant.AuditActionAccountLocked)
+	}
+
+	// The right password does not get through a lock
+	_, err = u.Login(ctx, dto.LoginRequest{Email: "jane@example.com", Password: "secret123"})
+	if !errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Fatalf("err = %v, want %v", err, ErrTooManyLoginAttempts)
+	}
+}
+
+func TestLoginForgetsFailuresOutsideWindow(t *testing.T) {
+	u, auth, _ := newTestAuthUsecase(t)
+	ctx := newTestCtx(t)
+
+	auth.attempts[constant.LoginAttemptScopeEmail+":nobody@example.com"] = entity.LoginAttempt{
+		FailedCount:  constant.LoginAccountLockThreshold - 1,
+		LastFailedAt: timeAgo(constant.LoginAttemptWindow + time.Minute),
+	}
+
+	_, err := u.Login(ctx, dto.LoginRequest{Email: "nobody@example.com", Password: "wrong"})
+	if err == nil || errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Fatalf("err = %v, want incorrect credentials", err)
+	}
+
+	attempt := auth.attempts[constant.LoginAttemptScopeEmail+":nobody@example.com"]
+	if attempt.FailedCount != 1 || attempt.LockedUntil != nil {
+		t.Fatalf("attempt = %+v, want a fresh count of 1 without lock", attempt)
+	}
+}
+
+func TestRecordFailedLoginCountsParallelFailures(t *testing.T) {
+	u, auth, _ := newTestAuthUsecase(t)
+	ctx := newTestCtx(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < constant.LoginIPLockThreshold; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := u.recordFailedLogin(ctx, constant.LoginAttemptScopeIP, "10.0.0.1", constant.LoginIPLockThreshold); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	attempt := auth.attempts[constant.LoginAttemptScopeIP+":10.0.0.1"]
+	if attempt.FailedCount != constant.LoginIPLockThreshold || attempt.LockedUntil == nil {
+		t.Fatalf("attempt = %+v, want %d failures and a lock", attempt, constant.LoginIPLockThreshold)
+	}
+}
+
+func TestRegisterNormalizesEmail(t *testing.T) {
+	u, _, _ := newTestAuthUsecase(t, entity.User{ID: uuid.New(), Email: "Taken@Example.com"})
+	ctx := newTestCtx(t)
+
+	err := u.Register(ctx, dto.RegisterRequest{Name: "Taken", Email: "taken@example.com", Password: "secret123"})
+	if err == nil {
+		t.Fatal("registering an email that differs only in case succeeded")
+	}
+
+	err = u.Register(ctx, dto.RegisterRequest{Name: "New", Email: " New@Example.com ", Password: "secret123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users := u.userRepo.(*fakeUserRepo).users
+	if got := users[len(users)-1].Email; got != "new@example.com" {
+		t.Fatalf("stored email = %q, want %q", got, "new@example.com")
+	}
+}
@@ -0,0 +1,536 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/menu/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	"github.com/jevvonn/sea-catering-be/internal/infra/i18n"
+	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
+	"github.com/jevvonn/sea-catering-be/internal/models"
+)
+
+type MenuHandler struct {
+	menuUsecase usecase.MenuUsecaseItf
+	validator   validator.ValidationService
+}
+
+func NewMenuHandler(
+	router fiber.Router,
+	mw *middleware.Middleware,
+	menuUsecase usecase.MenuUsecaseItf,
+	validator validator.ValidationService,
+) {
+	handler := MenuHandler{menuUsecase, validator}
+
+	router.Get("/plans/:id/menu", handler.GetWeeklyMenu)
+	router.Put("/plans/:id/menu", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.UpdateWeeklyMenu)
+
+	router.Get("/dishes", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.GetDishes)
+	router.Post("/dishes", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.CreateDish)
+	router.Put("/dishes/:id", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.UpdateDish)
+	router.Delete("/dishes/:id", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.DeleteDish)
+
+	router.Get("/allergens", handler.GetAllergens)
+	router.Post("/allergens", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.CreateAllergen)
+	router.Put("/allergens/:id", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.UpdateAllergen)
+	router.Delete("/allergens/:id", mw.Authenticated, mw.RequirePermission(constant.PermissionMenusWrite), handler.DeleteAllergen)
+
+	router.Get("/menus/production", mw.Authenticated, mw.RequirePermission(constant.PermissionKitchenRead), handler.GetProductionReport)
+	router.Get("/menus/allergen-conflicts", mw.Authenticated, mw.RequirePermission(constant.PermissionKitchenRead), handler.GetAllergenConflicts)
+}
+
+// @Tags         Menu
+// @Summary      Get Weekly Menu of a Plan
+// @Description  The week parameter accepts any date of the week, defaults to the current week.
+// @Accept       json
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Param        week query string false "Any date of the week (dd-mm-yyyy)"
+// @Router       /plans/{plansId}/menu [get]
+// @Success      200  {object}  models.JSONResponseModel{data=dto.WeeklyMenuResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) GetWeeklyMenu(ctx *fiber.Ctx) error {
+	var req dto.GetWeeklyMenuQuery
+	err := ctx.QueryParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	menu, err := h.menuUsecase.GetWeeklyMenu(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Failed to get weekly menu"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: i18n.Message(ctx, "Weekly menu retrieved successfully"),
+			Data:    menu,
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Set Weekly Menu of a Plan
+// @Description  Replaces every dish of the plan for the given week.
+// @Accept       json
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Param        request  body  dto.UpdateWeeklyMenuRequest  true  "Request body"
+// @Router       /plans/{plansId}/menu [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.WeeklyMenuResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) UpdateWeeklyMenu(ctx *fiber.Ctx) error {
+	var req dto.UpdateWeeklyMenuRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	menu, err := h.menuUsecase.UpdateWeeklyMenu(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update weekly menu",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Weekly menu updated successfully",
+			Data:    menu,
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Get Dishes
+// @Accept       json
+// @Produce      json
+// @Param        search query string false "Search by name"
+// @Router       /dishes [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.DishResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) GetDishes(ctx *fiber.Ctx) error {
+	var req dto.GetDishesQuery
+	err := ctx.QueryParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	dishes, err := h.menuUsecase.GetDishes(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get dishes",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Dishes retrieved successfully",
+			Data:    dishes,
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Create a Dish
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.CreateDishRequest  true  "Request body"
+// @Router       /dishes [post]
+// @Security     BearerAuth
+// @Success      201  {object}  models.JSONResponseModel{data=dto.DishResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) CreateDish(ctx *fiber.Ctx) error {
+	var req dto.CreateDishRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	dish, err := h.menuUsecase.CreateDish(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to create dish",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(
+		models.JSONResponseModel{
+			Message: "Dish created successfully",
+			Data:    dish,
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Update a Dish
+// @Accept       json
+// @Produce      json
+// @Param        dishId path string true "Dish ID"
+// @Param        request  body  dto.UpdateDishRequest  true  "Request body"
+// @Router       /dishes/{dishId} [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) UpdateDish(ctx *fiber.Ctx) error {
+	var req dto.UpdateDishRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.menuUsecase.UpdateDish(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update dish",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Dish updated successfully",
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Delete a Dish
+// @Description  Dishes used on a weekly menu cannot be deleted.
+// @Accept       json
+// @Produce      json
+// @Param        dishId path string true "Dish ID"
+// @Router       /dishes/{dishId} [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) DeleteDish(ctx *fiber.Ctx) error {
+	if err := h.menuUsecase.DeleteDish(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete dish",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Dish deleted successfully",
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Get Kitchen Production Report
+// @Description  Counts the portions of each dish to cook on a delivery date, defaults to today.
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        date query string false "Delivery date (dd-mm-yyyy)"
+// @Param        format query string false "json (default), csv or xlsx"
+// @Router       /menus/production [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.ProductionReportResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) GetProductionReport(ctx *fiber.Ctx) error {
+	var req dto.GetProductionQuery
+	err := ctx.QueryParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.menuUsecase.ExportProductionReport(req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to get production report",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "production", stream)
+	}
+
+	report, err := h.menuUsecase.GetProductionReport(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get production report",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Production report retrieved successfully",
+			Data:    report,
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Get Allergen Catalogue
+// @Accept       json
+// @Produce      json
+// @Router       /allergens [get]
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.AllergenResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) GetAllergens(ctx *fiber.Ctx) error {
+	allergens, err := h.menuUsecase.GetAllergens()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Failed to get allergens"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: i18n.Message(ctx, "Allergens retrieved successfully"),
+			Data:    allergens,
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Create an Allergen
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.CreateAllergenRequest  true  "Request body"
+// @Router       /allergens [post]
+// @Security     BearerAuth
+// @Success      201  {object}  models.JSONResponseModel{data=dto.AllergenResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) CreateAllergen(ctx *fiber.Ctx) error {
+	var req dto.CreateAllergenRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	allergen, err := h.menuUsecase.CreateAllergen(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to create allergen",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(
+		models.JSONResponseModel{
+			Message: "Allergen created successfully",
+			Data:    allergen,
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Update an Allergen
+// @Accept       json
+// @Produce      json
+// @Param        allergenId path string true "Allergen ID"
+// @Param        request  body  dto.UpdateAllergenRequest  true  "Request body"
+// @Router       /allergens/{allergenId} [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) UpdateAllergen(ctx *fiber.Ctx) error {
+	var req dto.UpdateAllergenRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.menuUsecase.UpdateAllergen(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update allergen",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Allergen updated successfully",
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Delete an Allergen
+// @Description  Allergens used by dishes or subscriptions cannot be deleted.
+// @Accept       json
+// @Produce      json
+// @Param        allergenId path string true "Allergen ID"
+// @Router       /allergens/{allergenId} [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) DeleteAllergen(ctx *fiber.Ctx) error {
+	if err := h.menuUsecase.DeleteAllergen(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete allergen",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Allergen deleted successfully",
+		},
+	)
+}
+
+// @Tags         Menu
+// @Summary      Get Allergen Conflicts for the Kitchen
+// @Description  Lists every dish to deliver on a date that contains an allergen declared by the subscriber, defaults to today.
+// @Accept       json
+// @Produce      json
+// @Param        date query string false "Delivery date (dd-mm-yyyy)"
+// @Router       /menus/allergen-conflicts [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.AllergenConflict}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *MenuHandler) GetAllergenConflicts(ctx *fiber.Ctx) error {
+	var req dto.GetAllergenConflictsQuery
+	err := ctx.QueryParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	conflicts, err := h.menuUsecase.GetAllergenConflicts(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get allergen conflicts",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Allergen conflicts retrieved successfully",
+			Data:    conflicts,
+		},
+	)
+}
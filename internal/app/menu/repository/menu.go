@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type MenuPostgreSQLItf interface {
+	GetDishes(query dto.GetDishesQuery) ([]entity.Dish, error)
+	GetSpecificDish(dish entity.Dish) (entity.Dish, error)
+	CreateDish(dish entity.Dish) error
+	UpdateDish(dishId uuid.UUID, fields map[string]any) error
+	ReplaceDishAllergens(dishId uuid.UUID, allergens []entity.Allergen) error
+	DeleteDish(dishId uuid.UUID) error
+	CountMenuItemsByDish(dishId uuid.UUID) (int64, error)
+	GetMenuItems(planId string, weekStart time.Time) ([]entity.MenuItem, error)
+	ReplaceWeeklyMenu(planId string, weekStart time.Time, items []entity.MenuItem) error
+	GetProductionItems(date time.Time) ([]dto.ProductionItem, error)
+	GetAllergens() ([]entity.Allergen, error)
+	GetAllergensByIDs(ids []uuid.UUID) ([]entity.Allergen, error)
+	GetSpecificAllergen(allergen entity.Allergen) (entity.Allergen, error)
+	CreateAllergen(allergen entity.Allergen) error
+	UpdateAllergen(allergenId uuid.UUID, fields map[string]any) error
+	DeleteAllergen(allergenId uuid.UUID) error
+	CountAllergenUsage(allergenId uuid.UUID) (int64, error)
+	GetAllergenConflicts(from time.Time, to time.Time, subscriptionId *uuid.UUID) ([]dto.AllergenConflict, error)
+}
+
+type MenuPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewMenuPostgreSQL(db *gorm.DB) MenuPostgreSQLItf {
+	return &MenuPostgreSQL{db}
+}
+
+func (r *MenuPostgreSQL) GetDishes(dishQuery dto.GetDishesQuery) ([]entity.Dish, error) {
+	var dishes []entity.Dish
+	query := r.db.Model(&entity.Dish{}).Preload("Allergens").Order("name")
+
+	if dishQuery.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+dishQuery.Search+"%")
+	}
+
+	if err := query.Find(&dishes).Error; err != nil {
+		return nil, err
+	}
+	return dishes, nil
+}
+
+func (r *MenuPostgreSQL) GetSpecificDish(dish entity.Dish) (entity.Dish, error) {
+	if err := r.db.Preload("Allergens").Where(&dish).First(&dish).Error; err != nil {
+		return entity.Dish{}, err
+	}
+	return dish, nil
+}
+
+func (r *MenuPostgreSQL) CreateDish(dish entity.Dish) error {
+	return r.db.Omit("Allergens.*").Create(&dish).Error
+}
+
+func (r *MenuPostgreSQL) UpdateDish(dishId uuid.UUID, fields map[string]any) error {
+	return r.db.Model(&entity.Dish{}).Where("id = ?", dishId).Updates(fields).Error
+}
+
+func (r *MenuPostgreSQL) ReplaceDishAllergens(dishId uuid.UUID, allergens []entity.Allergen) error {
+	association := r.db.Model(&entity.Dish{ID: dishId}).Omit("Allergens.*").Association("Allergens")
+	if len(allergens) == 0 {
+		return association.Clear()
+	}
+
+	return association.Replace(allergens)
+}
+
+func (r *MenuPostgreSQL) DeleteDish(dishId uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Dish{ID: dishId}).Association("Allergens").Clear(); err != nil {
+			return err
+		}
+
+		return tx.Delete(&entity.Dish{ID: dishId}).Error
+	})
+}
+
+func (r *MenuPostgreSQL) CountMenuItemsByDish(dishId uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.MenuItem{}).Where("dish_id = ?", dishId).Count(&count).Error
+	return count, err
+}
+
+func (r *MenuPostgreSQL) GetMenuItems(planId string, weekStart time.Time) ([]entity.MenuItem, error) {
+	var items []entity.MenuItem
+	err := r.db.Preload("Dish.Allergens").
+		Order("is_default DESC, created_at").
+		Where("plan_id = ? AND week_start = ?", planId, weekStart.Format("2006-01-02")).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *MenuPostgreSQL) ReplaceWeeklyMenu(planId string, weekStart time.Time, items []entity.MenuItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("plan_id = ? AND week_start = ?", planId, weekStart.Format("2006-01-02")).
+			Delete(&entity.MenuItem{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Plans", "Dish").Create(&items).Error
+	})
+}
+
+// servedMealsQuery lists every meal delivered to an active subscription between
+// @from and @to, skipping paused days, with the dish that will be served: the
+// subscriber's pick when it is still offered on the menu, otherwise the default
+// dish of the meal. The dish is empty when the menu has nothing scheduled yet.
+const servedMealsQuery = `
+	WITH deliveries AS (
+		SELECT s.id AS subscription_id, s.plan_id, g.date::date AS delivery_date,
+			to_char(g.date, 'FMDay') AS delivery_day, TRIM(m.mealtype) AS mealtype
+		FROM subscriptions s
+		CROSS JOIN generate_series(@from::date, @to::date, interval '1 day') AS g(date)
+		CROSS JOIN LATERAL unnest(string_to_array(s.mealtypes, ',')) AS m(mealtype)
+		WHERE s.status = @status
+			AND (CAST(@subscription AS uuid) IS NULL OR s.id = @subscription)
+			AND to_char(g.date, 'FMDay') = ANY(string_to_array(s.delivery_days, ','))
+			AND s.created_at::date <= g.date::date
+			AND NOT (
+				s.pause_start_date IS NOT NULL AND s.pause_end_date IS NOT NULL
+				AND g.date::date BETWEEN s.pause_start_date::date AND s.pause_end_date::date
+			)
+	)
+	SELECT dl.*, COALESCE(picked.dish_id, fallback.dish_id) AS dish_id
+	FROM deliveries dl
+	LEFT JOIN meal_selections ms ON ms.subscription_id = dl.subscription_id
+		AND ms.delivery_date = dl.delivery_date
+		AND ms.mealtype = dl.mealtype
+	LEFT JOIN menu_items picked ON picked.plan_id = dl.plan_id
+		AND picked.week_start = date_trunc('week', dl.delivery_date)::date
+		AND picked.delivery_day = dl.delivery_day
+		AND picked.mealtype = dl.mealtype
+		AND picked.dish_id = ms.dish_id
+	LEFT JOIN menu_items fallback ON fallback.plan_id = dl.plan_id
+		AND fallback.week_start = date_trunc('week', dl.delivery_date)::date
+		AND fallback.delivery_day = dl.delivery_day
+		AND fallback.mealtype = dl.mealtype
+		AND fallback.is_default
+`
+
+func servedMealsArgs(from time.Time, to time.Time, subscriptionId *uuid.UUID) map[string]any {
+	return map[string]any{
+		"from":         from.Format("2006-01-02"),
+		"to":           to.Format("2006-01-02"),
+		"status":       constant.SubscriptionStatusActive,
+		"subscription": subscriptionId,
+	}
+}
+
+// GetProductionItems counts the portions of every dish each plan and meal type
+// needs on the given date. Meals without a scheduled dish are returned with an
+// empty dish.
+func (r *MenuPostgreSQL) GetProductionItems(date time.Time) ([]dto.ProductionItem, error) {
+	var items []dto.ProductionItem
+
+	err := r.db.Raw(`
+		SELECT served.plan_id, served.mealtype, d.id AS dish_id, COALESCE(d.name, '') AS dish_name, COUNT(*) AS portions
+		FROM (`+servedMealsQuery+`) served
+		LEFT JOIN dishes d ON d.id = served.dish_id
+		GROUP BY served.plan_id, served.mealtype, d.id, d.name
+		ORDER BY served.plan_id, served.mealtype, portions DESC
+	`, servedMealsArgs(date, date, nil)).Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *MenuPostgreSQL) GetAllergens() ([]entity.Allergen, error) {
+	var allergens []entity.Allergen
+	if err := r.db.Order("name").Find(&allergens).Error; err != nil {
+		return nil, err
+	}
+	return allergens, nil
+}
+
+func (r *MenuPostgreSQL) GetAllergensByIDs(ids []uuid.UUID) ([]entity.Allergen, error) {
+	var allergens []entity.Allergen
+	if len(ids) == 0 {
+		return allergens, nil
+	}
+
+	if err := r.db.Where("id IN ?", ids).Order("name").Find(&allergens).Error; err != nil {
+		return nil, err
+	}
+	return allergens, nil
+}
+
+func (r *MenuPostgreSQL) GetSpecificAllergen(allergen entity.Allergen) (entity.Allergen, error) {
+	if err := r.db.Where(&allergen).First(&allergen).Error; err != nil {
+		return entity.Allergen{}, err
+	}
+	return allergen, nil
+}
+
+func (r *MenuPostgreSQL) CreateAllergen(allergen entity.Allergen) error {
+	return r.db.Create(&allergen).Error
+}
+
+func (r *MenuPostgreSQL) UpdateAllergen(allergenId uuid.UUID, fields map[string]any) error {
+	return r.db.Model(&entity.Allergen{}).Where("id = ?", allergenId).Updates(fields).Error
+}
+
+func (r *MenuPostgreSQL) DeleteAllergen(allergenId uuid.UUID) error {
+	return r.db.Delete(&entity.Allergen{ID: allergenId}).Error
+}
+
+// CountAllergenUsage counts the dishes and subscriptions referencing an allergen
+func (r *MenuPostgreSQL) CountAllergenUsage(allergenId uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Raw(`
+		SELECT (SELECT COUNT(*) FROM dish_allergens WHERE allergen_id = ?)
+			+ (SELECT COUNT(*) FROM subscription_allergens WHERE allergen_id = ?)
+	`, allergenId, allergenId).Scan(&count).Error
+	return count, err
+}
+
+// GetAllergenConflicts lists the meals served between two dates whose dish
+// contains an allergen declared on the subscription, optionally for a single
+// subscription.
+func (r *MenuPostgreSQL) GetAllergenConflicts(from time.Time, to time.Time, subscriptionId *uuid.UUID) ([]dto.AllergenConflict, error) {
+	var rows []struct {
+		SubscriptionID uuid.UUID
+		SubscriberName string
+		PhoneNumber    string
+		AllergyNote    string
+		PlanId         string
+		DeliveryDate   time.Time
+		DeliveryDay    string
+		Mealtype       string
+		DishID         uuid.UUID
+		DishName       string
+		Allergens      string
+	}
+
+	err := r.db.Raw(`
+		SELECT served.subscription_id, s.name AS subscriber_name, s.phone_number, s.allergy_note, served.plan_id,
+			served.delivery_date, served.delivery_day, served.mealtype, d.id AS dish_id, d.name AS dish_name,
+			json_agg(a.name ORDER BY a.name) AS allergens
+		FROM (`+servedMealsQuery+`) served
+		JOIN subscriptions s ON s.id = served.subscription_id
+		JOIN dishes d ON d.id = served.dish_id
+		JOIN dish_allergens da ON da.dish_id = d.id
+		JOIN subscription_allergens sa ON sa.subscription_id = s.id AND sa.allergen_id = da.allergen_id
+		JOIN allergens a ON a.id = da.allergen_id
+		GROUP BY served.subscription_id, s.id, served.plan_id, served.delivery_date, served.delivery_day, served.mealtype, d.id
+	`, servedMealsArgs(from, to, subscriptionId)).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]dto.AllergenConflict, 0, len(rows))
+	for _, row := range rows {
+		var allergens []string
+		if err := json.Unmarshal([]byte(row.Allergens), &allergens); err != nil {
+			return nil, err
+		}
+
+		conflicts = append(conflicts, dto.AllergenConflict{
+			SubscriptionID: row.SubscriptionID,
+			SubscriberName: row.SubscriberName,
+			PhoneNumber:    row.PhoneNumber,
+			AllergyNote:    row.AllergyNote,
+			PlanId:         row.PlanId,
+			Date:           row.DeliveryDate,
+			DeliveryDay:    row.DeliveryDay,
+			Mealtype:       row.Mealtype,
+			DishID:         row.DishID,
+			DishName:       row.DishName,
+			Allergens:      allergens,
+		})
+	}
+
+	slices.SortStableFunc(conflicts, func(a, b dto.AllergenConflict) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		if c := slices.Index(constant.Mealtypes, a.Mealtype) - slices.Index(constant.Mealtypes, b.Mealtype); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SubscriberName, b.SubscriberName)
+	})
+
+	return conflicts, nil
+}
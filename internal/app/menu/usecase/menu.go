@@ -0,0 +1,575 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/app/menu/repository"
+	plansRepo "github.com/jevvonn/sea-catering-be/internal/app/plans/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
+	"gorm.io/gorm"
+)
+
+type MenuUsecaseItf interface {
+	GetDishes(query dto.GetDishesQuery) ([]dto.DishResponse, error)
+	CreateDish(req dto.CreateDishRequest) (dto.DishResponse, error)
+	UpdateDish(ctx *fiber.Ctx, req dto.UpdateDishRequest) error
+	DeleteDish(ctx *fiber.Ctx) error
+	GetWeeklyMenu(ctx *fiber.Ctx, query dto.GetWeeklyMenuQuery) (dto.WeeklyMenuResponse, error)
+	UpdateWeeklyMenu(ctx *fiber.Ctx, req dto.UpdateWeeklyMenuRequest) (dto.WeeklyMenuResponse, error)
+	GetProductionReport(query dto.GetProductionQuery) (dto.ProductionReportResponse, error)
+	ExportProductionReport(query dto.GetProductionQuery) (export.StreamFunc, error)
+	GetAllergens() ([]dto.AllergenResponse, error)
+	CreateAllergen(req dto.CreateAllergenRequest) (dto.AllergenResponse, error)
+	UpdateAllergen(ctx *fiber.Ctx, req dto.UpdateAllergenRequest) error
+	DeleteAllergen(ctx *fiber.Ctx) error
+	GetAllergenConflicts(query dto.GetAllergenConflictsQuery) ([]dto.AllergenConflict, error)
+}
+
+type MenuUsecase struct {
+	menuRepo  repository.MenuPostgreSQLItf
+	plansRepo plansRepo.PlansPostgreSQLItf
+}
+
+func NewMenuUsecase(
+	menuRepo repository.MenuPostgreSQLItf,
+	plansRepo plansRepo.PlansPostgreSQLItf,
+) MenuUsecaseItf {
+	return &MenuUsecase{menuRepo, plansRepo}
+}
+
+func (u *MenuUsecase) GetDishes(query dto.GetDishesQuery) ([]dto.DishResponse, error) {
+	dishes, err := u.menuRepo.GetDishes(query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.DishResponse, 0, len(dishes))
+	for _, dish := range dishes {
+		result = append(result, ToDishResponse(dish))
+	}
+
+	return result, nil
+}
+
+func (u *MenuUsecase) CreateDish(req dto.CreateDishRequest) (dto.DishResponse, error) {
+	allergens, err := ResolveAllergens(u.menuRepo, req.AllergenIDs)
+	if err != nil {
+		return dto.DishResponse{}, err
+	}
+
+	dish := entity.Dish{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Ingredients: joinList(req.Ingredients),
+		Allergens:   allergens,
+	}
+
+	if err := u.menuRepo.CreateDish(dish); err != nil {
+		return dto.DishResponse{}, err
+	}
+
+	return ToDishResponse(dish), nil
+}
+
+func (u *MenuUsecase) UpdateDish(ctx *fiber.Ctx, req dto.UpdateDishRequest) error {
+	dish, err := u.getDish(ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]any{}
+	if req.Name != "" {
+		fields["name"] = strings.TrimSpace(req.Name)
+	}
+	if req.Description != "" {
+		fields["description"] = req.Description
+	}
+	if req.Ingredients != nil {
+		fields["ingredients"] = joinList(req.Ingredients)
+	}
+
+	// An empty allergen list is meaningful, it marks the dish as allergen free
+	if req.AllergenIDs != nil {
+		allergens, err := ResolveAllergens(u.menuRepo, req.AllergenIDs)
+		if err != nil {
+			return err
+		}
+
+		if err := u.menuRepo.ReplaceDishAllergens(dish.ID, allergens); err != nil {
+			return err
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return u.menuRepo.UpdateDish(dish.ID, fields)
+}
+
+func (u *MenuUsecase) DeleteDish(ctx *fiber.Ctx) error {
+	dish, err := u.getDish(ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	count, err := u.menuRepo.CountMenuItemsByDish(dish.ID)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("dish is used on a weekly menu")
+	}
+
+	return u.menuRepo.DeleteDish(dish.ID)
+}
+
+func (u *MenuUsecase) GetWeeklyMenu(ctx *fiber.Ctx, query dto.GetWeeklyMenuQuery) (dto.WeeklyMenuResponse, error) {
+	plan, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: ctx.Params("id")})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.WeeklyMenuResponse{}, errors.New("plan not found")
+		}
+		return dto.WeeklyMenuResponse{}, err
+	}
+
+	weekStart, err := parseWeek(query.Week)
+	if err != nil {
+		return dto.WeeklyMenuResponse{}, err
+	}
+
+	items, err := u.menuRepo.GetMenuItems(plan.ID, weekStart)
+	if err != nil {
+		return dto.WeeklyMenuResponse{}, err
+	}
+
+	return toWeeklyMenuResponse(plan.ID, weekStart, items), nil
+}
+
+func (u *MenuUsecase) UpdateWeeklyMenu(ctx *fiber.Ctx, req dto.UpdateWeeklyMenuRequest) (dto.WeeklyMenuResponse, error) {
+	plan, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: ctx.Params("id")})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.WeeklyMenuResponse{}, errors.New("plan not found")
+		}
+		return dto.WeeklyMenuResponse{}, err
+	}
+
+	weekStart, err := parseWeek(req.Week)
+	if err != nil {
+		return dto.WeeklyMenuResponse{}, err
+	}
+
+	items := make([]entity.MenuItem, 0, len(req.Items))
+	dishes := map[uuid.UUID]entity.Dish{}
+	for _, item := range req.Items {
+		dishId, err := uuid.Parse(item.DishID)
+		if err != nil {
+			return dto.WeeklyMenuResponse{}, errors.New("dish not found")
+		}
+
+		sameMeal := func(i entity.MenuItem) bool {
+			return i.DeliveryDay == item.DeliveryDay && i.Mealtype == item.Mealtype
+		}
+
+		if slices.ContainsFunc(items, func(i entity.MenuItem) bool { return sameMeal(i) && i.DishID == dishId }) {
+			return dto.WeeklyMenuResponse{}, fmt.Errorf("dish is offered twice for %s %s", item.DeliveryDay, item.Mealtype)
+		}
+
+		if item.IsDefault && slices.ContainsFunc(items, func(i entity.MenuItem) bool { return sameMeal(i) && i.IsDefault }) {
+			return dto.WeeklyMenuResponse{}, fmt.Errorf("more than one default dish for %s %s", item.DeliveryDay, item.Mealtype)
+		}
+
+		if _, ok := dishes[dishId]; !ok {
+			dish, err := u.getDish(item.DishID)
+			if err != nil {
+				return dto.WeeklyMenuResponse{}, err
+			}
+			dishes[dishId] = dish
+		}
+
+		items = append(items, entity.MenuItem{
+			ID:          uuid.New(),
+			PlanId:      plan.ID,
+			WeekStart:   weekStart,
+			DeliveryDay: item.DeliveryDay,
+			Mealtype:    item.Mealtype,
+			DishID:      dishId,
+			IsDefault:   item.IsDefault,
+		})
+	}
+
+	// Every meal needs exactly one default, fall back to its first dish
+	for i := range items {
+		hasDefault := slices.ContainsFunc(items, func(other entity.MenuItem) bool {
+			return other.DeliveryDay == items[i].DeliveryDay && other.Mealtype == items[i].Mealtype && other.IsDefault
+		})
+		if !hasDefault {
+			items[i].IsDefault = true
+		}
+	}
+
+	if err := u.menuRepo.ReplaceWeeklyMenu(plan.ID, weekStart, items); err != nil {
+		return dto.WeeklyMenuResponse{}, err
+	}
+
+	for i := range items {
+		items[i].Dish = dishes[items[i].DishID]
+	}
+
+	return toWeeklyMenuResponse(plan.ID, weekStart, items), nil
+}
+
+func (u *MenuUsecase) GetProductionReport(query dto.GetProductionQuery) (dto.ProductionReportResponse, error) {
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if query.Date != "" {
+		parsedDate, err := time.Parse("02-01-2006", query.Date)
+		if err != nil {
+			return dto.ProductionReportResponse{}, errors.New("invalid date format, expected dd-mm-yyyy")
+		}
+		date = parsedDate
+	}
+
+	items, err := u.menuRepo.GetProductionItems(date)
+	if err != nil {
+		return dto.ProductionReportResponse{}, err
+	}
+
+	report := dto.ProductionReportResponse{
+		Date:        date,
+		DeliveryDay: date.Weekday().String(),
+		Dishes:      []dto.ProductionDish{},
+		Items:       items,
+	}
+
+	for _, item := range items {
+		report.TotalPortions += item.Portions
+
+		index := slices.IndexFunc(report.Dishes, func(d dto.ProductionDish) bool {
+			if d.DishID == nil || item.DishID == nil {
+				return d.DishID == item.DishID
+			}
+			return *d.DishID == *item.DishID
+		})
+		if index == -1 {
+			report.Dishes = append(report.Dishes, dto.ProductionDish{
+				DishID:   item.DishID,
+				DishName: item.DishName,
+			})
+			index = len(report.Dishes) - 1
+		}
+		report.Dishes[index].Portions += item.Portions
+	}
+
+	slices.SortStableFunc(report.Dishes, func(a, b dto.ProductionDish) int {
+		return b.Portions - a.Portions
+	})
+
+	return report, nil
+}
+
+// ExportProductionReport exports one row per plan, mealtype and dish to cook
+func (u *MenuUsecase) ExportProductionReport(query dto.GetProductionQuery) (export.StreamFunc, error) {
+	report, err := u.GetProductionReport(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		err := w.WriteRow("date", "delivery_day", "plan_id", "mealtype", "dish_id", "dish_name", "portions")
+		if err != nil {
+			return err
+		}
+
+		for _, item := range report.Items {
+			dishId := ""
+			if item.DishID != nil {
+				dishId = item.DishID.String()
+			}
+
+			err := w.WriteRow(
+				report.Date.Format("2006-01-02"), report.DeliveryDay, item.PlanId, item.Mealtype, dishId, item.DishName, item.Portions,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+func (u *MenuUsecase) GetAllergens() ([]dto.AllergenResponse, error) {
+	allergens, err := u.menuRepo.GetAllergens()
+	if err != nil {
+		return nil, err
+	}
+
+	return ToAllergenResponses(allergens), nil
+}
+
+func (u *MenuUsecase) CreateAllergen(req dto.CreateAllergenRequest) (dto.AllergenResponse, error) {
+	code := utils.Slugify(req.Code)
+	if code == "" {
+		code = utils.Slugify(req.Name)
+	}
+
+	if code == "" {
+		return dto.AllergenResponse{}, errors.New("allergen code is required")
+	}
+
+	_, err := u.menuRepo.GetSpecificAllergen(entity.Allergen{Code: code})
+	if err == nil {
+		return dto.AllergenResponse{}, errors.New("allergen with this code already exists")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.AllergenResponse{}, err
+	}
+
+	allergen := entity.Allergen{
+		ID:          uuid.New(),
+		Code:        code,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+
+	if err := u.menuRepo.CreateAllergen(allergen); err != nil {
+		return dto.AllergenResponse{}, err
+	}
+
+	return toAllergenResponse(allergen), nil
+}
+
+func (u *MenuUsecase) UpdateAllergen(ctx *fiber.Ctx, req dto.UpdateAllergenRequest) error {
+	allergen, err := u.getAllergen(ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]any{}
+	if req.Name != "" {
+		fields["name"] = strings.TrimSpace(req.Name)
+	}
+	if req.Description != "" {
+		fields["description"] = req.Description
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return u.menuRepo.UpdateAllergen(allergen.ID, fields)
+}
+
+func (u *MenuUsecase) DeleteAllergen(ctx *fiber.Ctx) error {
+	allergen, err := u.getAllergen(ctx.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	count, err := u.menuRepo.CountAllergenUsage(allergen.ID)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("allergen is used by dishes or subscriptions")
+	}
+
+	return u.menuRepo.DeleteAllergen(allergen.ID)
+}
+
+func (u *MenuUsecase) GetAllergenConflicts(query dto.GetAllergenConflictsQuery) ([]dto.AllergenConflict, error) {
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if query.Date != "" {
+		parsedDate, err := time.Parse("02-01-2006", query.Date)
+		if err != nil {
+			return nil, errors.New("invalid date format, expected dd-mm-yyyy")
+		}
+		date = parsedDate
+	}
+
+	return u.menuRepo.GetAllergenConflicts(date, date, nil)
+}
+
+func (u *MenuUsecase) getAllergen(id string) (entity.Allergen, error) {
+	allergenId, err := uuid.Parse(id)
+	if err != nil {
+		return entity.Allergen{}, errors.New("allergen not found")
+	}
+
+	allergen, err := u.menuRepo.GetSpecificAllergen(entity.Allergen{ID: allergenId})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Allergen{}, errors.New("allergen not found")
+		}
+		return entity.Allergen{}, err
+	}
+
+	return allergen, nil
+}
+
+// ResolveAllergens loads the allergens of the given IDs, failing when one of them is invalid or
+// does not exist. Subscriptions declare allergens from the same catalogue, so it is shared.
+func ResolveAllergens(menuRepo repository.MenuPostgreSQLItf, ids []string) ([]entity.Allergen, error) {
+	allergenIds := []uuid.UUID{}
+	for _, id := range ids {
+		allergenId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errors.New("invalid allergen ID")
+		}
+
+		if !slices.Contains(allergenIds, allergenId) {
+			allergenIds = append(allergenIds, allergenId)
+		}
+	}
+
+	allergens, err := menuRepo.GetAllergensByIDs(allergenIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allergens) != len(allergenIds) {
+		return nil, errors.New("allergen not found")
+	}
+
+	return allergens, nil
+}
+
+func (u *MenuUsecase) getDish(id string) (entity.Dish, error) {
+	dishId, err := uuid.Parse(id)
+	if err != nil {
+		return entity.Dish{}, errors.New("dish not found")
+	}
+
+	dish, err := u.menuRepo.GetSpecificDish(entity.Dish{ID: dishId})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Dish{}, errors.New("dish not found")
+		}
+		return entity.Dish{}, err
+	}
+
+	return dish, nil
+}
+
+// parseWeek accepts any date in dd-mm-yyyy and returns the Monday of its
+// week, an empty value selects the current week
+func parseWeek(week string) (time.Time, error) {
+	date := time.Now()
+	if week != "" {
+		parsedDate, err := time.Parse("02-01-2006", week)
+		if err != nil {
+			return time.Time{}, errors.New("invalid week format, expected dd-mm-yyyy")
+		}
+		date = parsedDate
+	}
+
+	return utils.StartOfWeek(date), nil
+}
+
+func toWeeklyMenuResponse(planId string, weekStart time.Time, items []entity.MenuItem) dto.WeeklyMenuResponse {
+	menu := dto.WeeklyMenuResponse{
+		PlanId:    planId,
+		WeekStart: weekStart,
+		Days:      make([]dto.MenuDayResponse, 0, len(constant.DeliveryDays)),
+	}
+
+	for i, day := range constant.DeliveryDays {
+		menuDay := dto.MenuDayResponse{
+			DeliveryDay: day,
+			Date:        weekStart.AddDate(0, 0, i),
+			Meals:       []dto.MenuMealResponse{},
+		}
+
+		for _, mealtype := range constant.Mealtypes {
+			meal := dto.MenuMealResponse{
+				Mealtype: mealtype,
+				Options:  []dto.MenuOptionResponse{},
+			}
+
+			for _, item := range items {
+				if item.DeliveryDay != day || item.Mealtype != mealtype {
+					continue
+				}
+
+				meal.Options = append(meal.Options, dto.MenuOptionResponse{
+					IsDefault: item.IsDefault,
+					Dish:      ToDishResponse(item.Dish),
+				})
+			}
+
+			if len(meal.Options) > 0 {
+				menuDay.Meals = append(menuDay.Meals, meal)
+			}
+		}
+
+		menu.Days = append(menu.Days, menuDay)
+	}
+
+	return menu
+}
+
+func ToDishResponse(dish entity.Dish) dto.DishResponse {
+	return dto.DishResponse{
+		ID:          dish.ID,
+		Name:        dish.Name,
+		Description: dish.Description,
+		Ingredients: splitList(dish.Ingredients),
+		Allergens:   ToAllergenResponses(dish.Allergens),
+	}
+}
+
+func toAllergenResponse(allergen entity.Allergen) dto.AllergenResponse {
+	return dto.AllergenResponse{
+		ID:          allergen.ID,
+		Code:        allergen.Code,
+		Name:        allergen.Name,
+		Description: allergen.Description,
+	}
+}
+
+func ToAllergenResponses(allergens []entity.Allergen) []dto.AllergenResponse {
+	result := make([]dto.AllergenResponse, 0, len(allergens))
+	for _, allergen := range allergens {
+		result = append(result, toAllergenResponse(allergen))
+	}
+
+	return result
+}
+
+func joinList(values []string) string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+
+	return strings.Join(result, ",")
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
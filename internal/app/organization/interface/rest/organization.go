@@ -0,0 +1,705 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/organization/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
+	"github.com/jevvonn/sea-catering-be/internal/models"
+)
+
+type OrganizationHandler struct {
+	orgUsecase usecase.OrganizationUsecaseItf
+	validator  validator.ValidationService
+}
+
+func NewOrganizationHandler(
+	router fiber.Router,
+	mw *middleware.Middleware,
+	orgUsecase usecase.OrganizationUsecaseItf,
+	validator validator.ValidationService,
+) {
+	handler := OrganizationHandler{orgUsecase, validator}
+
+	router.Post("/organizations", mw.Authenticated, handler.CreateOrganization)
+	router.Get("/organizations", mw.Authenticated, mw.RequirePermission(constant.PermissionOrganizationsManage), handler.GetOrganizations)
+	router.Get("/organizations/mine", mw.Authenticated, handler.GetMyOrganization)
+
+	router.Get("/organizations/:id", mw.Authenticated, handler.GetOrganization)
+	router.Put("/organizations/:id", mw.Authenticated, handler.UpdateOrganization)
+	router.Get("/organizations/:id/members", mw.Authenticated, handler.GetMembers)
+	router.Post("/organizations/:id/members", mw.Authenticated, handler.AddMember)
+	router.Put("/organizations/:id/members/:userId", mw.Authenticated, handler.UpdateMember)
+	router.Delete("/organizations/:id/members/:userId", mw.Authenticated, handler.RemoveMember)
+	router.Get("/organizations/:id/subscriptions", mw.Authenticated, handler.GetSubscriptions)
+	router.Post("/organizations/:id/subscriptions", mw.Authenticated, handler.CreateSubscriptions)
+	router.Get("/organizations/:id/invoices", mw.Authenticated, handler.GetInvoices)
+	router.Post("/organizations/:id/invoices", mw.Authenticated, handler.CreateInvoice)
+	router.Get("/organizations/:id/invoices/:invoiceId", mw.Authenticated, handler.GetInvoice)
+	router.Put("/organizations/:id/invoices/:invoiceId/paid", mw.Authenticated, mw.RequirePermission(constant.PermissionOrganizationsManage), handler.MarkInvoicePaid)
+	router.Get("/organizations/:id/report", mw.Authenticated, handler.GetReport)
+}
+
+// @Tags         Organization
+// @Summary      Create Organization
+// @Description  Creates an organization with the current user as its admin. A user belongs to at most one organization.
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateOrganizationRequest true "Organization"
+// @Router       /organizations [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.OrganizationResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) CreateOrganization(ctx *fiber.Ctx) error {
+	var req dto.CreateOrganizationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	data, err := h.orgUsecase.CreateOrganization(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to create organization",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Organization created successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get All Organizations
+// @Accept       json
+// @Produce      json
+// @Param        search query string false "Search by name or billing email"
+// @Param        limit query int false "Limit, defaults to 10"
+// @Param        page query int false "Page, defaults to 1"
+// @Router       /organizations [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetOrganizationsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetOrganizations(ctx *fiber.Ctx) error {
+	var req dto.GetOrganizationsQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	data, err := h.orgUsecase.GetOrganizations(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get organizations",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Organizations retrieved successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get My Organization
+// @Accept       json
+// @Produce      json
+// @Router       /organizations/mine [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.OrganizationResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetMyOrganization(ctx *fiber.Ctx) error {
+	data, err := h.orgUsecase.GetMyOrganization(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get organization",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Organization retrieved successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get Organization
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Router       /organizations/{organizationId} [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.OrganizationResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetOrganization(ctx *fiber.Ctx) error {
+	data, err := h.orgUsecase.GetOrganization(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get organization",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Organization retrieved successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Update Organization
+// @Description  Organization admins only.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        request body dto.UpdateOrganizationRequest true "Organization"
+// @Router       /organizations/{organizationId} [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) UpdateOrganization(ctx *fiber.Ctx) error {
+	var req dto.UpdateOrganizationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.orgUsecase.UpdateOrganization(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update organization",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Organization updated successfully",
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get Organization Members
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Router       /organizations/{organizationId}/members [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.OrganizationMemberResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetMembers(ctx *fiber.Ctx) error {
+	data, err := h.orgUsecase.GetMembers(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get members",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Members retrieved successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Add Organization Member
+// @Description  Organization admins only. The user needs an account and must not belong to another organization.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        request body dto.AddOrganizationMemberRequest true "Member"
+// @Router       /organizations/{organizationId}/members [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) AddMember(ctx *fiber.Ctx) error {
+	var req dto.AddOrganizationMemberRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.orgUsecase.AddMember(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to add member",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Member added successfully",
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Update Organization Member Role
+// @Description  Organization admins only. The last admin cannot be demoted.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        userId path string true "User ID"
+// @Param        request body dto.UpdateOrganizationMemberRequest true "Role"
+// @Router       /organizations/{organizationId}/members/{userId} [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) UpdateMember(ctx *fiber.Ctx) error {
+	var req dto.UpdateOrganizationMemberRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.orgUsecase.UpdateMember(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update member",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Member updated successfully",
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Remove Organization Member
+// @Description  Organization admins remove members, members may remove themselves to leave. The last admin cannot be removed.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        userId path string true "User ID"
+// @Router       /organizations/{organizationId}/members/{userId} [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) RemoveMember(ctx *fiber.Ctx) error {
+	if err := h.orgUsecase.RemoveMember(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to remove member",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Member removed successfully",
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get Organization Subscriptions
+// @Description  Organization admins only.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        status query string false "Status (ACTIVE, CANCELLED)"
+// @Param        plan_id query string false "Plan ID"
+// @Param        search query string false "Search by user email"
+// @Param        sort query string false "Sort (newest, oldest, price_high, price_low), defaults to newest"
+// @Param        limit query int false "Limit, defaults to 10"
+// @Param        page query int false "Page, defaults to 1"
+// @Router       /organizations/{organizationId}/subscriptions [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetSubscriptionsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetSubscriptions(ctx *fiber.Ctx) error {
+	var req dto.GetSubscriptionsQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	data, err := h.orgUsecase.GetSubscriptions(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get subscriptions",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Subscriptions retrieved successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Create Organization Subscriptions
+// @Description  Organization admins only. Subscribes members to a plan billed to the organization, each with their own meals, delivery days and allergies. Missing contact details are taken from the member's profile. Either every subscription is created or none.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        request body dto.CreateOrganizationSubscriptionsRequest true "Subscriptions"
+// @Router       /organizations/{organizationId}/subscriptions [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.CreateOrganizationSubscriptionsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) CreateSubscriptions(ctx *fiber.Ctx) error {
+	var req dto.CreateOrganizationSubscriptionsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	data, err := h.orgUsecase.CreateSubscriptions(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to create subscriptions",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Subscriptions created successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get Organization Invoices
+// @Description  Organization admins only.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Router       /organizations/{organizationId}/invoices [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]entity.Invoice}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetInvoices(ctx *fiber.Ctx) error {
+	data, err := h.orgUsecase.GetInvoices(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get invoices",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Invoices retrieved successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Create Organization Invoice
+// @Description  Organization admins only. Bills every subscription of the organization active during the month in one invoice, a month is invoiced once.
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        request body dto.CreateInvoiceRequest true "Month"
+// @Router       /organizations/{organizationId}/invoices [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=entity.Invoice}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) CreateInvoice(ctx *fiber.Ctx) error {
+	var req dto.CreateInvoiceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	data, err := h.orgUsecase.CreateInvoice(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to create invoice",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Invoice created successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get Organization Invoice
+// @Description  Organization admins only. With format=csv or xlsx the invoice lines are downloaded as a file.
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        organizationId path string true "Organization ID"
+// @Param        invoiceId path string true "Invoice ID"
+// @Param        format query string false "json (default), csv or xlsx"
+// @Router       /organizations/{organizationId}/invoices/{invoiceId} [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=entity.Invoice}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetInvoice(ctx *fiber.Ctx) error {
+	var req dto.GetInvoiceQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.orgUsecase.ExportInvoice(ctx)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to get invoice",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "invoice", stream)
+	}
+
+	data, err := h.orgUsecase.GetInvoice(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get invoice",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Invoice retrieved successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Mark Organization Invoice Paid
+// @Accept       json
+// @Produce      json
+// @Param        organizationId path string true "Organization ID"
+// @Param        invoiceId path string true "Invoice ID"
+// @Router       /organizations/{organizationId}/invoices/{invoiceId}/paid [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) MarkInvoicePaid(ctx *fiber.Ctx) error {
+	if err := h.orgUsecase.MarkInvoicePaid(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to mark invoice as paid",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Invoice marked as paid successfully",
+		},
+	)
+}
+
+// @Tags         Organization
+// @Summary      Get Organization Report
+// @Description  Organization admins only. Members, active subscriptions and MRR per plan, with new subscriptions, cancellations and invoiced amounts per month. With format=csv or xlsx the months are downloaded as a file.
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        organizationId path string true "Organization ID"
+// @Param        start_month query string false "Start month (mm-yyyy), defaults to 11 months before the end month"
+// @Param        end_month query string false "End month (mm-yyyy), defaults to the current month"
+// @Param        format query string false "json (default), csv or xlsx"
+// @Router       /organizations/{organizationId}/report [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.OrganizationReportResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *OrganizationHandler) GetReport(ctx *fiber.Ctx) error {
+	var req dto.GetOrganizationReportQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.orgUsecase.ExportReport(ctx, req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to get organization report",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "organization-report", stream)
+	}
+
+	data, err := h.orgUsecase.GetReport(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get organization report",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Organization report retrieved successfully",
+			Data:    data,
+		},
+	)
+}
@@ -0,0 +1,362 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationPostgreSQLItf interface {
+	WithTx(tx *gorm.DB) OrganizationPostgreSQLItf
+
+	CreateOrganization(organization entity.Organization, adminId uuid.UUID) error
+	GetOrganization(organizationId uuid.UUID) (entity.Organization, error)
+	GetOrganizations(query dto.GetOrganizationsQuery) ([]entity.Organization, int64, error)
+	UpdateOrganization(organization entity.Organization) error
+
+	GetMembership(userId uuid.UUID) (entity.OrganizationMember, error)
+	GetMembers(organizationId uuid.UUID) ([]entity.OrganizationMember, error)
+	AddMember(member entity.OrganizationMember) error
+	UpdateMemberRole(organizationId uuid.UUID, userId uuid.UUID, role string) error
+	RemoveMember(organizationId uuid.UUID, userId uuid.UUID) error
+	RemoveUserMembership(userId uuid.UUID) error
+	CountMembers(organizationId uuid.UUID, role string) (int64, error)
+
+	GetInvoiceableSubscriptions(organizationId uuid.UUID, from time.Time, to time.Time) ([]entity.Subscription, error)
+	CreateInvoice(invoice entity.Invoice) error
+	GetInvoices(organizationId uuid.UUID) ([]entity.Invoice, error)
+	GetInvoice(organizationId uuid.UUID, invoiceId uuid.UUID) (entity.Invoice, error)
+	MarkInvoicePaid(invoiceId uuid.UUID, paidAt time.Time) error
+
+	GetPlanTotals(organizationId uuid.UUID) ([]dto.OrganizationPlanTotal, error)
+	GetOrganizationMonths(organizationId uuid.UUID, from time.Time, to time.Time) ([]dto.OrganizationMonth, error)
+}
+
+type OrganizationPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewOrganizationPostgreSQL(db *gorm.DB) OrganizationPostgreSQLItf {
+	return &OrganizationPostgreSQL{db}
+}
+
+// WithTx runs the repository on a transaction of another repository
+func (r *OrganizationPostgreSQL) WithTx(tx *gorm.DB) OrganizationPostgreSQLItf {
+	return &OrganizationPostgreSQL{tx}
+}
+
+// CreateOrganization creates the organization with its creator as first admin
+func (r *OrganizationPostgreSQL) CreateOrganization(organization entity.Organization, adminId uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&organization).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&entity.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         adminId,
+			Role:           constant.OrganizationRoleAdmin,
+		}).Error
+	})
+}
+
+func (r *OrganizationPostgreSQL) GetOrganization(organizationId uuid.UUID) (entity.Organization, error) {
+	var organization entity.Organization
+	err := r.db.First(&organization, "id = ?", organizationId).Error
+	return organization, err
+}
+
+func (r *OrganizationPostgreSQL) GetOrganizations(query dto.GetOrganizationsQuery) ([]entity.Organization, int64, error) {
+	var organizations []entity.Organization
+	var total int64
+
+	db := r.db.Model(&entity.Organization{})
+	if query.Search != "" {
+		search := "%" + query.Search + "%"
+		db = db.Where("name ILIKE ? OR billing_email ILIKE ?", search, search)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("name").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&organizations).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return organizations, total, nil
+}
+
+func (r *OrganizationPostgreSQL) UpdateOrganization(organization entity.Organization) error {
+	data := map[string]any{}
+
+	if organization.Name != "" {
+		data["name"] = organization.Name
+	}
+
+	if organization.BillingEmail != "" {
+		data["billing_email"] = organization.BillingEmail
+	}
+
+	if organization.BillingAddress != "" {
+		data["billing_address"] = organization.BillingAddress
+	}
+
+	return r.db.Model(&entity.Organization{}).Where("id = ?", organization.ID).Updates(data).Error
+}
+
+func (r *OrganizationPostgreSQL) GetMembership(userId uuid.UUID) (entity.OrganizationMember, error) {
+	var member entity.OrganizationMember
+	err := r.db.First(&member, "user_id = ?", userId).Error
+	return member, err
+}
+
+func (r *OrganizationPostgreSQL) GetMembers(organizationId uuid.UUID) ([]entity.OrganizationMember, error) {
+	var members []entity.OrganizationMember
+	err := r.db.Preload("User").
+		Where("organization_id = ?", organizationId).
+		Order("created_at").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *OrganizationPostgreSQL) AddMember(member entity.OrganizationMember) error {
+	return r.db.Create(&member).Error
+}
+
+// UpdateMemberRole changes the role of a member, an organization can not demote its last admin
+func (r *OrganizationPostgreSQL) UpdateMemberRole(organizationId uuid.UUID, userId uuid.UUID, role string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if role != constant.OrganizationRoleAdmin {
+			if err := ensureAnotherAdmin(tx, organizationId, userId); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entity.OrganizationMember{}).
+			Where("organization_id = ? AND user_id = ?", organizationId, userId).
+			Update("role", role).Error
+	})
+}
+
+// RemoveMember removes a member, an organization can not lose its last admin
+func (r *OrganizationPostgreSQL) RemoveMember(organizationId uuid.UUID, userId uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureAnotherAdmin(tx, organizationId, userId); err != nil {
+			return err
+		}
+
+		return tx.Where("organization_id = ? AND user_id = ?", organizationId, userId).
+			Delete(&entity.OrganizationMember{}).Error
+	})
+}
+
+// RemoveUserMembership removes the user from their organization. When they were its last admin
+// the longest standing other member becomes admin, so the organization keeps someone to manage it.
+func (r *OrganizationPostgreSQL) RemoveUserMembership(userId uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var member entity.OrganizationMember
+		err := tx.Where("user_id = ?", userId).First(&member).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := lockOrganization(tx, member.OrganizationID); err != nil {
+			return err
+		}
+
+		result := tx.Where("organization_id = ? AND user_id = ?", member.OrganizationID, userId).
+			Delete(&entity.OrganizationMember{})
+		if result.Error != nil || result.RowsAffected == 0 || member.Role != constant.OrganizationRoleAdmin {
+			return result.Error
+		}
+
+		var admins int64
+		err = tx.Model(&entity.OrganizationMember{}).
+			Where("organization_id = ? AND role = ?", member.OrganizationID, constant.OrganizationRoleAdmin).
+			Count(&admins).Error
+		if err != nil || admins > 0 {
+			return err
+		}
+
+		var successor entity.OrganizationMember
+		err = tx.Where("organization_id = ?", member.OrganizationID).Order("created_at").First(&successor).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.OrganizationMember{}).
+			Where("organization_id = ? AND user_id = ?", successor.OrganizationID, successor.UserID).
+			Update("role", constant.OrganizationRoleAdmin).Error
+	})
+}
+
+// lockOrganization locks the organization row until the transaction ends, so two admins leaving
+// or being demoted at once can not both count on the other to stay admin
+func lockOrganization(tx *gorm.DB, organizationId uuid.UUID) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&entity.Organization{}, "id = ?", organizationId).Error
+}
+
+// ensureAnotherAdmin locks the organization and fails when the user is its only admin
+func ensureAnotherAdmin(tx *gorm.DB, organizationId uuid.UUID, userId uuid.UUID) error {
+	if err := lockOrganization(tx, organizationId); err != nil {
+		return err
+	}
+
+	var admins int64
+	err := tx.Model(&entity.OrganizationMember{}).
+		Where("organization_id = ? AND role = ? AND user_id <> ?", organizationId, constant.OrganizationRoleAdmin, userId).
+		Count(&admins).Error
+	if err != nil {
+		return err
+	}
+
+	var isAdmin int64
+	err = tx.Model(&entity.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ? AND role = ?", organizationId, userId, constant.OrganizationRoleAdmin).
+		Count(&isAdmin).Error
+	if err != nil {
+		return err
+	}
+
+	if isAdmin > 0 && admins == 0 {
+		return errors.New("an organization needs at least one admin")
+	}
+
+	return nil
+}
+
+// CountMembers counts the members of an organization, only those with the role when given
+func (r *OrganizationPostgreSQL) CountMembers(organizationId uuid.UUID, role string) (int64, error) {
+	var count int64
+
+	query := r.db.Model(&entity.OrganizationMember{}).Where("organization_id = ?", organizationId)
+	if role != "" {
+		query = query.Where("role = ?", role)
+	}
+
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// GetInvoiceableSubscriptions returns the subscriptions of the organization that were active
+// at some point from from until before to
+func (r *OrganizationPostgreSQL) GetInvoiceableSubscriptions(organizationId uuid.UUID, from time.Time, to time.Time) ([]entity.Subscription, error) {
+	var subscriptions []entity.Subscription
+	err := r.db.Preload("Plans").Preload("User").
+		Where("organization_id = ? AND created_at < ?", organizationId, to).
+		Where("(cancelled_at IS NULL OR cancelled_at >= ?)", from).
+		Order("created_at").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *OrganizationPostgreSQL) CreateInvoice(invoice entity.Invoice) error {
+	return r.db.Create(&invoice).Error
+}
+
+func (r *OrganizationPostgreSQL) GetInvoices(organizationId uuid.UUID) ([]entity.Invoice, error) {
+	var invoices []entity.Invoice
+	err := r.db.Where("organization_id = ?", organizationId).
+		Order("period_start DESC").
+		Find(&invoices).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+func (r *OrganizationPostgreSQL) GetInvoice(organizationId uuid.UUID, invoiceId uuid.UUID) (entity.Invoice, error) {
+	var invoice entity.Invoice
+	err := r.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("member_name")
+	}).First(&invoice, "id = ? AND organization_id = ?", invoiceId, organizationId).Error
+	return invoice, err
+}
+
+func (r *OrganizationPostgreSQL) MarkInvoicePaid(invoiceId uuid.UUID, paidAt time.Time) error {
+	return r.db.Model(&entity.Invoice{}).Where("id = ?", invoiceId).Updates(map[string]any{
+		"status":  constant.InvoiceStatusPaid,
+		"paid_at": paidAt,
+	}).Error
+}
+
+// GetPlanTotals counts the active subscriptions of the organization and their MRR per plan
+func (r *OrganizationPostgreSQL) GetPlanTotals(organizationId uuid.UUID) ([]dto.OrganizationPlanTotal, error) {
+	var totals []dto.OrganizationPlanTotal
+	err := r.db.Table("subscriptions s").
+		Select("s.plan_id, COALESCE(p.name, '') AS plan_name, COUNT(*) AS active_subscriptions, SUM(s.total_price) AS mrr").
+		Joins("LEFT JOIN plans p ON p.id = s.plan_id").
+		Where("s.organization_id = ? AND s.status = ?", organizationId, constant.SubscriptionStatusActive).
+		Group("s.plan_id, p.name").
+		Order("p.name").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// GetOrganizationMonths counts the new and cancelled subscriptions of the organization and sums
+// its invoices for every month from the month of from to the month of to
+func (r *OrganizationPostgreSQL) GetOrganizationMonths(organizationId uuid.UUID, from time.Time, to time.Time) ([]dto.OrganizationMonth, error) {
+	var months []dto.OrganizationMonth
+	err := r.db.Raw(
+		`WITH months AS (
+			SELECT month::date AS month_start, (month + interval '1 month')::date AS month_end
+			FROM generate_series(@from::date, @to::date, interval '1 month') AS month
+		)
+		SELECT
+			m.month_start,
+			(SELECT COUNT(*) FROM subscription_events e JOIN subscriptions s ON s.id = e.subscription_id
+				WHERE s.organization_id = @organization AND e.type = @created
+				AND e.occurred_at >= m.month_start AND e.occurred_at < m.month_end) AS new_subscriptions,
+			(SELECT COUNT(*) FROM subscription_events e JOIN subscriptions s ON s.id = e.subscription_id
+				WHERE s.organization_id = @organization AND e.type = @cancelled
+				AND e.occurred_at >= m.month_start AND e.occurred_at < m.month_end) AS cancellations,
+			(SELECT COALESCE(SUM(i.total), 0) FROM invoices i
+				WHERE i.organization_id = @organization AND i.period_start = m.month_start) AS invoiced
+		FROM months m
+		ORDER BY m.month_start`,
+		map[string]any{
+			"organization": organizationId,
+			"from":         from.Format("2006-01-02"),
+			"to":           to.Format("2006-01-02"),
+			"created":      constant.SubscriptionEventCreated,
+			"cancelled":    constant.SubscriptionEventCancelled,
+		},
+	).Scan(&months).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return months, nil
+}
@@ -0,0 +1,625 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	"github.com/jevvonn/sea-catering-be/internal/app/organization/repository"
+	permissionUsecase "github.com/jevvonn/sea-catering-be/internal/app/permission/usecase"
+	subsUsecase "github.com/jevvonn/sea-catering-be/internal/app/subscription/usecase"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
+	"gorm.io/gorm"
+)
+
+type OrganizationUsecaseItf interface {
+	CreateOrganization(ctx *fiber.Ctx, req dto.CreateOrganizationRequest) (dto.OrganizationResponse, error)
+	GetOrganizations(query dto.GetOrganizationsQuery) (dto.GetOrganizationsResponse, error)
+	GetMyOrganization(ctx *fiber.Ctx) (dto.OrganizationResponse, error)
+	GetOrganization(ctx *fiber.Ctx) (dto.OrganizationResponse, error)
+	UpdateOrganization(ctx *fiber.Ctx, req dto.UpdateOrganizationRequest) error
+
+	GetMembers(ctx *fiber.Ctx) ([]dto.OrganizationMemberResponse, error)
+	AddMember(ctx *fiber.Ctx, req dto.AddOrganizationMemberRequest) error
+	UpdateMember(ctx *fiber.Ctx, req dto.UpdateOrganizationMemberRequest) error
+	RemoveMember(ctx *fiber.Ctx) error
+
+	GetSubscriptions(ctx *fiber.Ctx, query dto.GetSubscriptionsQuery) (dto.GetSubscriptionsResponse, error)
+	CreateSubscriptions(ctx *fiber.Ctx, req dto.CreateOrganizationSubscriptionsRequest) (dto.CreateOrganizationSubscriptionsResponse, error)
+
+	GetInvoices(ctx *fiber.Ctx) ([]entity.Invoice, error)
+	CreateInvoice(ctx *fiber.Ctx, req dto.CreateInvoiceRequest) (entity.Invoice, error)
+	GetInvoice(ctx *fiber.Ctx) (entity.Invoice, error)
+	ExportInvoice(ctx *fiber.Ctx) (export.StreamFunc, error)
+	MarkInvoicePaid(ctx *fiber.Ctx) error
+
+	GetReport(ctx *fiber.Ctx, query dto.GetOrganizationReportQuery) (dto.OrganizationReportResponse, error)
+	ExportReport(ctx *fiber.Ctx, query dto.GetOrganizationReportQuery) (export.StreamFunc, error)
+}
+
+type OrganizationUsecase struct {
+	orgRepo           repository.OrganizationPostgreSQLItf
+	userRepo          userRepo.UserPostgreSQLItf
+	subsUsecase       subsUsecase.SubscriptionUsecaseItf
+	permissionUsecase permissionUsecase.PermissionUsecaseItf
+	auditRepo         auditRepo.AuditPostgreSQLItf
+}
+
+func NewOrganizationUsecase(
+	orgRepo repository.OrganizationPostgreSQLItf,
+	userRepo userRepo.UserPostgreSQLItf,
+	subsUsecase subsUsecase.SubscriptionUsecaseItf,
+	permissionUsecase permissionUsecase.PermissionUsecaseItf,
+	auditRepo auditRepo.AuditPostgreSQLItf,
+) OrganizationUsecaseItf {
+	return &OrganizationUsecase{orgRepo, userRepo, subsUsecase, permissionUsecase, auditRepo}
+}
+
+func (u *OrganizationUsecase) CreateOrganization(ctx *fiber.Ctx, req dto.CreateOrganizationRequest) (dto.OrganizationResponse, error) {
+	userId := uuid.MustParse(ctx.Locals("userId").(string))
+
+	_, err := u.orgRepo.GetMembership(userId)
+	if err == nil {
+		return dto.OrganizationResponse{}, errors.New("you are already a member of an organization")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.OrganizationResponse{}, err
+	}
+
+	organization := entity.Organization{
+		ID:             uuid.New(),
+		Name:           req.Name,
+		BillingEmail:   req.BillingEmail,
+		BillingAddress: req.BillingAddress,
+	}
+
+	if err := u.orgRepo.CreateOrganization(organization, userId); err != nil {
+		return dto.OrganizationResponse{}, err
+	}
+
+	organization, err = u.orgRepo.GetOrganization(organization.ID)
+	if err != nil {
+		return dto.OrganizationResponse{}, err
+	}
+
+	return toOrganizationResponse(organization, constant.OrganizationRoleAdmin), nil
+}
+
+func (u *OrganizationUsecase) GetOrganizations(query dto.GetOrganizationsQuery) (dto.GetOrganizationsResponse, error) {
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+
+	organizations, total, err := u.orgRepo.GetOrganizations(query)
+	if err != nil {
+		return dto.GetOrganizationsResponse{}, err
+	}
+
+	response := dto.GetOrganizationsResponse{
+		Organizations: []dto.OrganizationResponse{},
+		Total:         total,
+		Page:          query.Page,
+		Limit:         query.Limit,
+	}
+	for _, organization := range organizations {
+		response.Organizations = append(response.Organizations, toOrganizationResponse(organization, ""))
+	}
+
+	return response, nil
+}
+
+func (u *OrganizationUsecase) GetMyOrganization(ctx *fiber.Ctx) (dto.OrganizationResponse, error) {
+	userId := uuid.MustParse(ctx.Locals("userId").(string))
+
+	member, err := u.orgRepo.GetMembership(userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.OrganizationResponse{}, errors.New("you are not a member of an organization")
+		}
+		return dto.OrganizationResponse{}, err
+	}
+
+	organization, err := u.orgRepo.GetOrganization(member.OrganizationID)
+	if err != nil {
+		return dto.OrganizationResponse{}, err
+	}
+
+	return toOrganizationResponse(organization, member.Role), nil
+}
+
+func (u *OrganizationUsecase) GetOrganization(ctx *fiber.Ctx) (dto.OrganizationResponse, error) {
+	organization, role, err := u.getAccessibleOrganization(ctx, false)
+	if err != nil {
+		return dto.OrganizationResponse{}, err
+	}
+
+	return toOrganizationResponse(organization, role), nil
+}
+
+func (u *OrganizationUsecase) UpdateOrganization(ctx *fiber.Ctx, req dto.UpdateOrganizationRequest) error {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	return u.orgRepo.UpdateOrganization(entity.Organization{
+		ID:             organization.ID,
+		Name:           req.Name,
+		BillingEmail:   req.BillingEmail,
+		BillingAddress: req.BillingAddress,
+	})
+}
+
+func (u *OrganizationUsecase) GetMembers(ctx *fiber.Ctx) ([]dto.OrganizationMemberResponse, error) {
+	organization, _, err := u.getAccessibleOrganization(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := u.orgRepo.GetMembers(organization.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []dto.OrganizationMemberResponse{}
+	for _, member := range members {
+		response = append(response, dto.OrganizationMemberResponse{
+			UserID:   member.UserID,
+			Name:     member.User.Name,
+			Email:    member.User.Email,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (u *OrganizationUsecase) AddMember(ctx *fiber.Ctx, req dto.AddOrganizationMemberRequest) error {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.GetUserByEmail(strings.TrimSpace(req.Email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("no user with this email")
+		}
+		return err
+	}
+
+	if user.AnonymizedAt != nil || !user.IsActive {
+		return errors.New("user account is not active")
+	}
+
+	_, err = u.orgRepo.GetMembership(user.ID)
+	if err == nil {
+		return errors.New("user is already a member of an organization")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	err = u.orgRepo.AddMember(entity.OrganizationMember{
+		OrganizationID: organization.ID,
+		UserID:         user.ID,
+		Role:           req.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.createAuditLog(ctx, constant.AuditActionOrganizationMemberAdded, "ORGANIZATION", organization.ID.String(),
+		fmt.Sprintf("user %s added as %s", user.ID, req.Role))
+}
+
+func (u *OrganizationUsecase) UpdateMember(ctx *fiber.Ctx, req dto.UpdateOrganizationMemberRequest) error {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	member, err := u.getMember(ctx, organization.ID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == req.Role {
+		return nil
+	}
+
+	if err := u.orgRepo.UpdateMemberRole(organization.ID, member.UserID, req.Role); err != nil {
+		return err
+	}
+
+	return u.createAuditLog(ctx, constant.AuditActionOrganizationRoleChanged, "ORGANIZATION", organization.ID.String(),
+		fmt.Sprintf("user %s changed from %s to %s", member.UserID, member.Role, req.Role))
+}
+
+// RemoveMember removes a member from the organization, members may also leave by removing
+// themselves. Their subscriptions stay billed to the organization until they are cancelled.
+func (u *OrganizationUsecase) RemoveMember(ctx *fiber.Ctx) error {
+	userId := ctx.Locals("userId").(string)
+
+	organization, _, err := u.getAccessibleOrganization(ctx, ctx.Params("userId") != userId)
+	if err != nil {
+		return err
+	}
+
+	member, err := u.getMember(ctx, organization.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.orgRepo.RemoveMember(organization.ID, member.UserID); err != nil {
+		return err
+	}
+
+	return u.createAuditLog(ctx, constant.AuditActionOrganizationMemberRemoved, "ORGANIZATION", organization.ID.String(),
+		fmt.Sprintf("user %s removed", member.UserID))
+}
+
+func (u *OrganizationUsecase) GetSubscriptions(ctx *fiber.Ctx, query dto.GetSubscriptionsQuery) (dto.GetSubscriptionsResponse, error) {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return dto.GetSubscriptionsResponse{}, err
+	}
+
+	return u.subsUsecase.GetOrganizationSubscriptions(organization.ID, query)
+}
+
+func (u *OrganizationUsecase) CreateSubscriptions(ctx *fiber.Ctx, req dto.CreateOrganizationSubscriptionsRequest) (dto.CreateOrganizationSubscriptionsResponse, error) {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return dto.CreateOrganizationSubscriptionsResponse{}, err
+	}
+
+	return u.subsUsecase.CreateOrganizationSubscriptions(organization.ID, req)
+}
+
+func (u *OrganizationUsecase) GetInvoices(ctx *fiber.Ctx) ([]entity.Invoice, error) {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.orgRepo.GetInvoices(organization.ID)
+}
+
+// CreateInvoice bills every subscription of the organization that was active during the month.
+// Subscriptions are billed for the whole month, with their retention discount while it lasts.
+func (u *OrganizationUsecase) CreateInvoice(ctx *fiber.Ctx, req dto.CreateInvoiceRequest) (entity.Invoice, error) {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	month, err := time.ParseInLocation(utils.MonthLayout, req.Month, export.Location)
+	if err != nil {
+		return entity.Invoice{}, errors.New("invalid month format, expected mm-yyyy")
+	}
+
+	if month.After(time.Now()) {
+		return entity.Invoice{}, errors.New("cannot invoice a future month")
+	}
+
+	invoices, err := u.orgRepo.GetInvoices(organization.ID)
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	for _, invoice := range invoices {
+		if invoice.PeriodStart.Format(utils.MonthLayout) == month.Format(utils.MonthLayout) {
+			return entity.Invoice{}, errors.New("this month has already been invoiced")
+		}
+	}
+
+	periodEnd := month.AddDate(0, 1, 0)
+	subscriptions, err := u.orgRepo.GetInvoiceableSubscriptions(organization.ID, month, periodEnd)
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	if len(subscriptions) == 0 {
+		return entity.Invoice{}, errors.New("no subscriptions to invoice for this month")
+	}
+
+	invoice := entity.Invoice{
+		ID:             uuid.New(),
+		OrganizationID: organization.ID,
+		PeriodStart:    month,
+		PeriodEnd:      periodEnd.AddDate(0, 0, -1),
+		Status:         constant.InvoiceStatusIssued,
+		IssuedAt:       time.Now(),
+	}
+	invoice.Number = fmt.Sprintf("INV-%s-%s", month.Format("200601"), strings.ToUpper(invoice.ID.String()[:8]))
+
+	for _, subscription := range subscriptions {
+		line := newInvoiceLine(subscription, month)
+		invoice.Lines = append(invoice.Lines, line)
+		invoice.Total += line.Amount
+	}
+
+	invoice.Total = math.Round(invoice.Total*100) / 100
+
+	if err := u.orgRepo.CreateInvoice(invoice); err != nil {
+		return entity.Invoice{}, err
+	}
+
+	err = u.createAuditLog(ctx, constant.AuditActionInvoiceIssued, "INVOICE", invoice.ID.String(),
+		fmt.Sprintf("%s for %s, total %.2f", invoice.Number, req.Month, invoice.Total))
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+func (u *OrganizationUsecase) GetInvoice(ctx *fiber.Ctx) (entity.Invoice, error) {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return entity.Invoice{}, err
+	}
+
+	invoiceId, err := uuid.Parse(ctx.Params("invoiceId"))
+	if err != nil {
+		return entity.Invoice{}, errors.New("invalid invoice ID")
+	}
+
+	invoice, err := u.orgRepo.GetInvoice(organization.ID, invoiceId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Invoice{}, errors.New("invoice not found")
+		}
+		return entity.Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+func (u *OrganizationUsecase) ExportInvoice(ctx *fiber.Ctx) (export.StreamFunc, error) {
+	invoice, err := u.GetInvoice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		err := w.WriteRow(
+			"invoice_number", "period_start", "period_end", "subscription_id", "member_name", "member_email",
+			"plan", "mealtype", "delivery_days", "price", "discount_percent", "amount",
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range invoice.Lines {
+			err := w.WriteRow(
+				invoice.Number, invoice.PeriodStart.Format("02-01-2006"), invoice.PeriodEnd.Format("02-01-2006"),
+				line.SubscriptionID.String(), line.MemberName, line.MemberEmail, line.PlanName, line.Mealtypes,
+				line.DeliveryDays, line.Price, line.DiscountPercent, line.Amount,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return w.WriteRow("total", "", "", "", "", "", "", "", "", "", "", invoice.Total)
+	}, nil
+}
+
+func (u *OrganizationUsecase) MarkInvoicePaid(ctx *fiber.Ctx) error {
+	invoice, err := u.GetInvoice(ctx)
+	if err != nil {
+		return err
+	}
+
+	if invoice.Status == constant.InvoiceStatusPaid {
+		return errors.New("invoice is already paid")
+	}
+
+	if err := u.orgRepo.MarkInvoicePaid(invoice.ID, time.Now()); err != nil {
+		return err
+	}
+
+	return u.createAuditLog(ctx, constant.AuditActionInvoicePaid, "INVOICE", invoice.ID.String(), invoice.Number)
+}
+
+func (u *OrganizationUsecase) GetReport(ctx *fiber.Ctx, query dto.GetOrganizationReportQuery) (dto.OrganizationReportResponse, error) {
+	organization, _, err := u.getAccessibleOrganization(ctx, true)
+	if err != nil {
+		return dto.OrganizationReportResponse{}, err
+	}
+
+	startMonth, endMonth, err := utils.ParseMonthRange(query.StartMonth, query.EndMonth)
+	if err != nil {
+		return dto.OrganizationReportResponse{}, err
+	}
+
+	members, err := u.orgRepo.CountMembers(organization.ID, "")
+	if err != nil {
+		return dto.OrganizationReportResponse{}, err
+	}
+
+	plans, err := u.orgRepo.GetPlanTotals(organization.ID)
+	if err != nil {
+		return dto.OrganizationReportResponse{}, err
+	}
+
+	months, err := u.orgRepo.GetOrganizationMonths(organization.ID, startMonth, endMonth)
+	if err != nil {
+		return dto.OrganizationReportResponse{}, err
+	}
+
+	response := dto.OrganizationReportResponse{
+		OrganizationID: organization.ID,
+		StartMonth:     startMonth.Format(utils.MonthLayout),
+		EndMonth:       endMonth.Format(utils.MonthLayout),
+		Members:        members,
+		Plans:          plans,
+		Months:         months,
+	}
+
+	for _, plan := range plans {
+		response.ActiveSubscriptions += plan.ActiveSubscriptions
+		response.MRR += plan.MRR
+	}
+
+	for i := range response.Months {
+		response.Months[i].Month = response.Months[i].MonthStart.Format(utils.MonthLayout)
+	}
+
+	response.MRR = math.Round(response.MRR*100) / 100
+	return response, nil
+}
+
+func (u *OrganizationUsecase) ExportReport(ctx *fiber.Ctx, query dto.GetOrganizationReportQuery) (export.StreamFunc, error) {
+	report, err := u.GetReport(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		err := w.WriteRow("month", "new_subscriptions", "cancellations", "invoiced")
+		if err != nil {
+			return err
+		}
+
+		for _, month := range report.Months {
+			err := w.WriteRow(month.Month, month.NewSubscriptions, month.Cancellations, month.Invoiced)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// getAccessibleOrganization returns the organization in the id param with the role of the current
+// user in it. Members can read it, only its admins can manage it unless the user may manage every
+// organization.
+func (u *OrganizationUsecase) getAccessibleOrganization(ctx *fiber.Ctx, requireAdmin bool) (entity.Organization, string, error) {
+	userId := ctx.Locals("userId").(string)
+	role := ctx.Locals("role").(string)
+
+	organizationId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return entity.Organization{}, "", errors.New("invalid organization ID")
+	}
+
+	organization, err := u.orgRepo.GetOrganization(organizationId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Organization{}, "", errors.New("organization not found")
+		}
+		return entity.Organization{}, "", err
+	}
+
+	member, err := u.orgRepo.GetMembership(uuid.MustParse(userId))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.Organization{}, "", err
+	}
+
+	memberRole := ""
+	if member.OrganizationID == organization.ID {
+		memberRole = member.Role
+	}
+
+	if memberRole == constant.OrganizationRoleAdmin || (memberRole != "" && !requireAdmin) {
+		return organization, memberRole, nil
+	}
+
+	canManageAny, err := u.permissionUsecase.HasPermission(role, constant.PermissionOrganizationsManage)
+	if err != nil {
+		return entity.Organization{}, "", err
+	}
+
+	if !canManageAny {
+		return entity.Organization{}, "", errors.New("unauthorized access to organization")
+	}
+
+	return organization, memberRole, nil
+}
+
+// getMember returns the member in the userId param of the organization
+func (u *OrganizationUsecase) getMember(ctx *fiber.Ctx, organizationId uuid.UUID) (entity.OrganizationMember, error) {
+	userId, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return entity.OrganizationMember{}, errors.New("invalid user ID")
+	}
+
+	member, err := u.orgRepo.GetMembership(userId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.OrganizationMember{}, err
+	}
+
+	if member.OrganizationID != organizationId {
+		return entity.OrganizationMember{}, errors.New("member not found")
+	}
+
+	return member, nil
+}
+
+func (u *OrganizationUsecase) createAuditLog(ctx *fiber.Ctx, action string, targetType string, targetId string, details string) error {
+	actorId := uuid.MustParse(ctx.Locals("userId").(string))
+
+	return u.auditRepo.CreateAuditLog(entity.AuditLog{
+		ActorID:    &actorId,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetId,
+		IPAddress:  ctx.IP(),
+		Details:    details,
+	})
+}
+
+// newInvoiceLine bills a subscription for the month starting at month, with its retention
+// discount when the discount lasts into that month
+func newInvoiceLine(subscription entity.Subscription, month time.Time) entity.InvoiceLine {
+	discountPercent := 0
+	if subscription.DiscountEndsAt != nil && subscription.DiscountEndsAt.After(month) {
+		discountPercent = subscription.DiscountPercent
+	}
+
+	return entity.InvoiceLine{
+		ID:              uuid.New(),
+		SubscriptionID:  subscription.ID,
+		MemberName:      subscription.Name,
+		MemberEmail:     subscription.User.Email,
+		PlanName:        subscription.Plans.Name,
+		Mealtypes:       subscription.Mealtypes,
+		DeliveryDays:    subscription.DeliveryDays,
+		Price:           subscription.TotalPrice,
+		DiscountPercent: discountPercent,
+		Amount:          math.Round(subscription.TotalPrice*float64(100-discountPercent)) / 100,
+	}
+}
+
+func toOrganizationResponse(organization entity.Organization, role string) dto.OrganizationResponse {
+	return dto.OrganizationResponse{
+		ID:             organization.ID,
+		Name:           organization.Name,
+		BillingEmail:   organization.BillingEmail,
+		BillingAddress: organization.BillingAddress,
+		Role:           role,
+		CreatedAt:      organization.CreatedAt,
+		UpdatedAt:      organization.UpdatedAt,
+	}
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	"github.com/jevvonn/sea-catering-be/internal/app/organization/repository"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	"gorm.io/gorm"
+)
+
+type fakeOrgRepo struct {
+	repository.OrganizationPostgreSQLItf
+
+	organization  entity.Organization
+	members       []entity.OrganizationMember
+	subscriptions []entity.Subscription
+	invoices      []entity.Invoice
+}
+
+func (r *fakeOrgRepo) GetOrganization(organizationId uuid.UUID) (entity.Organization, error) {
+	if organizationId != r.organization.ID {
+		return entity.Organization{}, gorm.ErrRecordNotFound
+	}
+
+	return r.organization, nil
+}
+
+func (r *fakeOrgRepo) GetMembership(userId uuid.UUID) (entity.OrganizationMember, error) {
+	for _, member := range r.members {
+		if member.UserID == userId {
+			return member, nil
+		}
+	}
+
+	return entity.OrganizationMember{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeOrgRepo) AddMember(member entity.OrganizationMember) error {
+	r.members = append(r.members, member)
+	return nil
+}
+
+func (r *fakeOrgRepo) GetInvoices(organizationId uuid.UUID) ([]entity.Invoice, error) {
+	return r.invoices, nil
+}
+
+func (r *fakeOrgRepo) GetInvoiceableSubscriptions(organizationId uuid.UUID, from time.Time, to time.Time) ([]entity.Subscription, error) {
+	return r.subscriptions, nil
+}
+
+func (r *fakeOrgRepo) CreateInvoice(invoice entity.Invoice) error {
+	r.invoices = append(r.invoices, invoice)
+	return nil
+}
+
+type fakeUserRepo struct {
+	userRepo.UserPostgreSQLItf
+
+	users []entity.User
+}
+
+func (r *fakeUserRepo) GetUserByEmail(email string) (entity.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+
+	return entity.User{}, gorm.ErrRecordNotFound
+}
+
+type fakeAuditRepo struct {
+	auditRepo.AuditPostgreSQLItf
+
+	logs []entity.AuditLog
+}
+
+func (r *fakeAuditRepo) CreateAuditLog(log entity.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+// newTestOrganization returns a usecase for an organization administered by the returned user
+func newTestOrganization(users ...entity.User) (*OrganizationUsecase, *fakeOrgRepo, *fakeAuditRepo, uuid.UUID) {
+	adminId := uuid.New()
+	org := &fakeOrgRepo{organization: entity.Organization{ID: uuid.New(), Name: "Acme"}}
+	org.members = append(org.members, entity.OrganizationMember{
+		OrganizationID: org.organization.ID,
+		UserID:         adminId,
+		Role:           constant.OrganizationRoleAdmin,
+	})
+
+	audit := &fakeAuditRepo{}
+	return &OrganizationUsecase{orgRepo: org, userRepo: &fakeUserRepo{users: users}, auditRepo: audit}, org, audit, adminId
+}
+
+// call runs fn inside a request to the organization as the given user
+func call(t *testing.T, organizationId uuid.UUID, userId uuid.UUID, fn func(ctx *fiber.Ctx) error) error {
+	t.Helper()
+
+	var err error
+	app := fiber.New()
+	app.Get("/:id", func(ctx *fiber.Ctx) error {
+		ctx.Locals("userId", userId.String())
+		ctx.Locals("role", constant.RoleUser)
+		err = fn(ctx)
+		return nil
+	})
+
+	if _, testErr := app.Test(httptest.NewRequest(fiber.MethodGet, "/"+organizationId.String(), nil)); testErr != nil {
+		t.Fatal(testErr)
+	}
+
+	return err
+}
+
+func TestNewInvoiceLine(t *testing.T) {
+	month := time.Date(2025, time.March, 1, 0, 0, 0, 0, export.Location)
+	runningUntil := month.AddDate(0, 0, 10)
+
+	tests := []struct {
+		name         string
+		endsAt       *time.Time
+		wantDiscount int
+		wantAmount   float64
+	}{
+		{"no discount", nil, 0, 129.99},
+		{"discount lasting into the month", &runningUntil, 20, 103.99},
+		{"discount ended with the previous month", &month, 0, 129.99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := entity.Subscription{
+				ID:              uuid.New(),
+				Name:            "Jane Doe",
+				User:            entity.User{Email: "jane@example.com"},
+				Plans:           entity.Plans{Name: "Diet Plan"},
+				TotalPrice:      129.99,
+				DiscountPercent: 20,
+				DiscountEndsAt:  tt.endsAt,
+			}
+
+			line := newInvoiceLine(subscription, month)
+			if line.DiscountPercent != tt.wantDiscount || line.Amount != tt.wantAmount {
+				t.Fatalf("line = %d%% / %v, want %d%% / %v", line.DiscountPercent, line.Amount, tt.wantDiscount, tt.wantAmount)
+			}
+			if line.Price != 129.99 || line.SubscriptionID != subscription.ID || line.MemberEmail != "jane@example.com" || line.PlanName != "Diet Plan" {
+				t.Fatalf("line = %+v, want the subscription details copied", line)
+			}
+		})
+	}
+}
+
+func TestCreateInvoice(t *testing.T) {
+	u, org, audit, adminId := newTestOrganization()
+	discountEndsAt := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	org.subscriptions = []entity.Subscription{
+		{ID: uuid.New(), TotalPrice: 129.99, DiscountPercent: 20, DiscountEndsAt: &discountEndsAt},
+		{ID: uuid.New(), TotalPrice: 80.5},
+		{ID: uuid.New(), TotalPrice: 0.01},
+	}
+
+	var invoice entity.Invoice
+	err := call(t, org.organization.ID, adminId, func(ctx *fiber.Ctx) error {
+		var err error
+		invoice, err = u.CreateInvoice(ctx, dto.CreateInvoiceRequest{Month: "03-2025"})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(invoice.Lines) != 3 || invoice.Total != 184.5 {
+		t.Fatalf("invoice has %d lines totalling %v, want 3 totalling 184.5", len(invoice.Lines), invoice.Total)
+	}
+	if got := invoice.PeriodStart.Format("02-01-2006") + " " + invoice.PeriodEnd.Format("02-01-2006"); got != "01-03-2025 31-03-2025" {
+		t.Fatalf("period = %s, want the whole of March", got)
+	}
+	if !strings.HasPrefix(invoice.Number, "INV-202503-") || invoice.Status != constant.InvoiceStatusIssued {
+		t.Fatalf("invoice = %s %s", invoice.Number, invoice.Status)
+	}
+	if len(org.invoices) != 1 || len(audit.logs) != 1 || audit.logs[0].Action != constant.AuditActionInvoiceIssued {
+		t.Fatalf("stored %d invoices and audit logs %+v", len(org.invoices), audit.logs)
+	}
+}
+
+func TestCreateInvoiceRejects(t *testing.T) {
+	nextMonth := time.Now().AddDate(0, 1, 0).Format("01-2006")
+
+	tests := []struct {
+		name          string
+		month         string
+		subscriptions []entity.Subscription
+		want          string
+	}{
+		{"invalid month", "2025-03", []entity.Subscription{{TotalPrice: 10}}, "invalid month format, expected mm-yyyy"},
+		{"future month", nextMonth, []entity.Subscription{{TotalPrice: 10}}, "cannot invoice a future month"},
+		{"already invoiced", "02-2025", []entity.Subscription{{TotalPrice: 10}}, "this month has already been invoiced"},
+		{"nothing to bill", "03-2025", nil, "no subscriptions to invoice for this month"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, org, _, adminId := newTestOrganization()
+			org.subscriptions = tt.subscriptions
+			org.invoices = []entity.Invoice{{PeriodStart: time.Date(2025, time.February, 1, 0, 0, 0, 0, export.Location)}}
+
+			err := call(t, org.organization.ID, adminId, func(ctx *fiber.Ctx) error {
+				_, err := u.CreateInvoice(ctx, dto.CreateInvoiceRequest{Month: tt.month})
+				return err
+			})
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+			if len(org.invoices) != 1 {
+				t.Fatal("rejected invoice was stored")
+			}
+		})
+	}
+}
+
+func TestAddMemberIgnoresEmailCase(t *testing.T) {
+	user := entity.User{ID: uuid.New(), Email: "Jane.Doe@Example.com", IsActive: true}
+	u, org, _, adminId := newTestOrganization(user)
+
+	err := call(t, org.organization.ID, adminId, func(ctx *fiber.Ctx) error {
+		return u.AddMember(ctx, dto.AddOrganizationMemberRequest{Email: " jane.doe@example.com ", Role: constant.OrganizationRoleMember})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member, err := org.GetMembership(user.ID)
+	if err != nil || member.OrganizationID != org.organization.ID || member.Role != constant.OrganizationRoleMember {
+		t.Fatalf("membership = %+v (err %v), want a member of the organization", member, err)
+	}
+}
@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/permission/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
+	"github.com/jevvonn/sea-catering-be/internal/models"
+)
+
+type PermissionHandler struct {
+	permissionUsecase usecase.PermissionUsecaseItf
+	validator         validator.ValidationService
+}
+
+func NewPermissionHandler(
+	router fiber.Router,
+	mw *middleware.Middleware,
+	permissionUsecase usecase.PermissionUsecaseItf,
+	validator validator.ValidationService,
+) {
+	handler := PermissionHandler{permissionUsecase, validator}
+
+	router.Get("/permissions", mw.Authenticated, mw.RequirePermission(constant.PermissionPermissionsManage), handler.GetPermissions)
+	router.Get("/roles", mw.Authenticated, mw.RequirePermission(constant.PermissionPermissionsManage), handler.GetRoles)
+	router.Get("/roles/:role/permissions", mw.Authenticated, mw.RequirePermission(constant.PermissionPermissionsManage), handler.GetRolePermissions)
+	router.Put("/roles/:role/permissions", mw.Authenticated, mw.RequirePermission(constant.PermissionPermissionsManage), handler.UpdateRolePermissions)
+}
+
+// @Tags         Permission
+// @Summary      Get All Permissions
+// @Accept       json
+// @Produce      json
+// @Router       /permissions [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]entity.Permission}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PermissionHandler) GetPermissions(ctx *fiber.Ctx) error {
+	permissions, err := h.permissionUsecase.GetPermissions()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve permissions",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Permissions retrieved successfully",
+			Data:    permissions,
+		},
+	)
+}
+
+// @Tags         Permission
+// @Summary      Get All Roles with Their Permissions
+// @Accept       json
+// @Produce      json
+// @Router       /roles [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.GetRolePermissionsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PermissionHandler) GetRoles(ctx *fiber.Ctx) error {
+	roles, err := h.permissionUsecase.GetRoles()
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve roles",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Roles retrieved successfully",
+			Data:    roles,
+		},
+	)
+}
+
+// @Tags         Permission
+// @Summary      Get Role Permissions
+// @Accept       json
+// @Produce      json
+// @Param        role path string true "Role, e.g ADMIN"
+// @Router       /roles/{role}/permissions [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetRolePermissionsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PermissionHandler) GetRolePermissions(ctx *fiber.Ctx) error {
+	rolePermissions, err := h.permissionUsecase.GetRolePermissions(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve role permissions",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Role permissions retrieved successfully",
+			Data:    rolePermissions,
+		},
+	)
+}
+
+// @Tags         Permission
+// @Summary      Replace Role Permissions
+// @Description  Assigning permissions to an unknown role creates it.
+// @Accept       json
+// @Produce      json
+// @Param        role path string true "Role, e.g ADMIN"
+// @Param        request body dto.UpdateRolePermissionsRequest true "Request body"
+// @Router       /roles/{role}/permissions [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PermissionHandler) UpdateRolePermissions(ctx *fiber.Ctx) error {
+	var req dto.UpdateRolePermissionsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.permissionUsecase.UpdateRolePermissions(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update role permissions",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Role permissions updated successfully",
+		},
+	)
+}
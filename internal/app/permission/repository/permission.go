@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type PermissionPostgreSQLItf interface {
+	GetPermissions() ([]entity.Permission, error)
+	GetRolePermissions() ([]entity.RolePermission, error)
+	GetPermissionsByRole(role string) ([]entity.RolePermission, error)
+	ReplaceRolePermissions(role string, permissionIds []string) error
+}
+
+type PermissionPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewPermissionPostgreSQL(db *gorm.DB) PermissionPostgreSQLItf {
+	return &PermissionPostgreSQL{db}
+}
+
+func (r *PermissionPostgreSQL) GetPermissions() ([]entity.Permission, error) {
+	var permissions []entity.Permission
+	if err := r.db.Order("id").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *PermissionPostgreSQL) GetRolePermissions() ([]entity.RolePermission, error) {
+	var rolePermissions []entity.RolePermission
+	if err := r.db.Order("role, permission_id").Find(&rolePermissions).Error; err != nil {
+		return nil, err
+	}
+
+	return rolePermissions, nil
+}
+
+func (r *PermissionPostgreSQL) GetPermissionsByRole(role string) ([]entity.RolePermission, error) {
+	var rolePermissions []entity.RolePermission
+	err := r.db.Where(&entity.RolePermission{Role: role}).Order("permission_id").Find(&rolePermissions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rolePermissions, nil
+}
+
+func (r *PermissionPostgreSQL) ReplaceRolePermissions(role string, permissionIds []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&entity.RolePermission{Role: role}).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		if len(permissionIds) == 0 {
+			return nil
+		}
+
+		rolePermissions := make([]entity.RolePermission, 0, len(permissionIds))
+		for _, permissionId := range permissionIds {
+			rolePermissions = append(rolePermissions, entity.RolePermission{
+				Role:         role,
+				PermissionID: permissionId,
+			})
+		}
+
+		return tx.Create(&rolePermissions).Error
+	})
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	"github.com/jevvonn/sea-catering-be/internal/app/permission/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+)
+
+type PermissionUsecaseItf interface {
+	GetPermissions() ([]entity.Permission, error)
+	GetRoles() ([]dto.GetRolePermissionsResponse, error)
+	GetRolePermissions(ctx *fiber.Ctx) (dto.GetRolePermissionsResponse, error)
+	UpdateRolePermissions(ctx *fiber.Ctx, req dto.UpdateRolePermissionsRequest) error
+	HasPermission(role string, permission string) (bool, error)
+	RoleExists(role string) (bool, error)
+}
+
+var rolePattern = regexp.MustCompile(`^[A-Z][A-Z_]{1,49}$`)
+
+type PermissionUsecase struct {
+	permissionRepo repository.PermissionPostgreSQLItf
+	auditRepo      auditRepo.AuditPostgreSQLItf
+
+	// Role permissions are checked on almost every request, so they are cached
+	// until an admin changes them
+	mu    sync.RWMutex
+	cache map[string]map[string]bool
+}
+
+func NewPermissionUsecase(
+	permissionRepo repository.PermissionPostgreSQLItf,
+	auditRepo auditRepo.AuditPostgreSQLItf,
+) PermissionUsecaseItf {
+	return &PermissionUsecase{
+		permissionRepo: permissionRepo,
+		auditRepo:      auditRepo,
+	}
+}
+
+func (u *PermissionUsecase) GetPermissions() ([]entity.Permission, error) {
+	return u.permissionRepo.GetPermissions()
+}
+
+func (u *PermissionUsecase) GetRoles() ([]dto.GetRolePermissionsResponse, error) {
+	rolePermissions, err := u.loadRolePermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	roles := slices.Clone(constant.Roles)
+	for role := range rolePermissions {
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	slices.Sort(roles)
+
+	response := []dto.GetRolePermissionsResponse{}
+	for _, role := range roles {
+		response = append(response, dto.GetRolePermissionsResponse{
+			Role:        role,
+			Permissions: sortedPermissions(rolePermissions[role]),
+		})
+	}
+
+	return response, nil
+}
+
+func (u *PermissionUsecase) GetRolePermissions(ctx *fiber.Ctx) (dto.GetRolePermissionsResponse, error) {
+	role := strings.ToUpper(ctx.Params("role"))
+
+	rolePermissions, err := u.loadRolePermissions()
+	if err != nil {
+		return dto.GetRolePermissionsResponse{}, err
+	}
+
+	if _, ok := rolePermissions[role]; !ok && !slices.Contains(constant.Roles, role) {
+		return dto.GetRolePermissionsResponse{}, errors.New("role not found")
+	}
+
+	return dto.GetRolePermissionsResponse{
+		Role:        role,
+		Permissions: sortedPermissions(rolePermissions[role]),
+	}, nil
+}
+
+func (u *PermissionUsecase) UpdateRolePermissions(ctx *fiber.Ctx, req dto.UpdateRolePermissionsRequest) error {
+	userId := ctx.Locals("userId").(string)
+	role := strings.ToUpper(ctx.Params("role"))
+
+	if !rolePattern.MatchString(role) {
+		return errors.New("role must be 2-50 uppercase letters or underscores")
+	}
+
+	permissions, err := u.permissionRepo.GetPermissions()
+	if err != nil {
+		return err
+	}
+
+	permissionIds := []string{}
+	for _, requested := range req.Permissions {
+		if slices.Contains(permissionIds, requested) {
+			continue
+		}
+
+		found := slices.ContainsFunc(permissions, func(p entity.Permission) bool {
+			return p.ID == requested
+		})
+		if !found {
+			return errors.New("unknown permission " + requested)
+		}
+
+		permissionIds = append(permissionIds, requested)
+	}
+
+	// Prevent admins from locking themselves out of permission management
+	if role == constant.RoleAdmin && !slices.Contains(permissionIds, constant.PermissionPermissionsManage) {
+		return errors.New("ADMIN role must keep the " + constant.PermissionPermissionsManage + " permission")
+	}
+
+	if err := u.permissionRepo.ReplaceRolePermissions(role, permissionIds); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	u.cache = nil
+	u.mu.Unlock()
+
+	actorId := uuid.MustParse(userId)
+	return u.auditRepo.CreateAuditLog(entity.AuditLog{
+		ActorID:    &actorId,
+		Action:     constant.AuditActionRolePermissionsUpdated,
+		TargetType: "ROLE",
+		TargetID:   role,
+		IPAddress:  ctx.IP(),
+		Details:    strings.Join(permissionIds, ","),
+	})
+}
+
+func (u *PermissionUsecase) HasPermission(role string, permission string) (bool, error) {
+	rolePermissions, err := u.loadRolePermissions()
+	if err != nil {
+		return false, err
+	}
+
+	return rolePermissions[role][permission], nil
+}
+
+func (u *PermissionUsecase) RoleExists(role string) (bool, error) {
+	if slices.Contains(constant.Roles, role) {
+		return true, nil
+	}
+
+	rolePermissions, err := u.loadRolePermissions()
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := rolePermissions[role]
+	return ok, nil
+}
+
+func (u *PermissionUsecase) loadRolePermissions() (map[string]map[string]bool, error) {
+	u.mu.RLock()
+	cache := u.cache
+	u.mu.RUnlock()
+
+	if cache != nil {
+		return cache, nil
+	}
+
+	rolePermissions, err := u.permissionRepo.GetRolePermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	cache = map[string]map[string]bool{}
+	for _, rp := range rolePermissions {
+		if cache[rp.Role] == nil {
+			cache[rp.Role] = map[string]bool{}
+		}
+		cache[rp.Role][rp.PermissionID] = true
+	}
+
+	u.mu.Lock()
+	u.cache = cache
+	u.mu.Unlock()
+
+	return cache, nil
+}
+
+func sortedPermissions(permissions map[string]bool) []string {
+	result := []string{}
+	for permission := range permissions {
+		result = append(result, permission)
+	}
+	slices.Sort(result)
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/jevvonn/sea-catering-be/internal/app/plans/usecase"
 	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/i18n"
 	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
 	"github.com/jevvonn/sea-catering-be/internal/middleware"
 	"github.com/jevvonn/sea-catering-be/internal/models"
@@ -17,36 +18,49 @@ type PlansHandler struct {
 
 func NewPlansHandler(
 	router fiber.Router,
+	mw *middleware.Middleware,
 	plansUsecase usecase.PlansUsecaseItf,
 	validator validator.ValidationService,
 ) {
 	handler := PlansHandler{plansUsecase, validator}
 
 	router.Get("/plans", handler.GetPlans)
-	router.Put("/plans/:id", middleware.Authenticated, middleware.RequireRoles(constant.RoleAdmin), handler.UpdatePlan)
+	router.Get("/plans/all", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.GetAllPlans)
+	router.Post("/plans", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.CreatePlan)
+	router.Put("/plans/order", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.ReorderPlans)
+	router.Put("/plans/:id", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.UpdatePlan)
+	router.Post("/plans/:id/archive", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.ArchivePlan)
+	router.Post("/plans/:id/unarchive", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.UnarchivePlan)
+	router.Put("/plans/:id/image", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.UploadPlanImage)
+	router.Delete("/plans/:id/image", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.DeletePlanImage)
+	router.Put("/plans/:id/translations/:locale", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.SavePlanTranslation)
+	router.Delete("/plans/:id/translations/:locale", mw.Authenticated, mw.RequirePermission(constant.PermissionPlansWrite), handler.DeletePlanTranslation)
 }
 
 // @Tags         Plans
 // @Summary      Get All Meal Plans
+// @Description  Archived plans are not listed. Content is returned in the language negotiated from Accept-Language or the lang parameter.
 // @Accept       json
 // @Produce      json
+// @Param        Accept-Language header string false "Preferred languages, e.g. id-ID,id;q=0.9"
+// @Param        lang query string false "Language override (en, id)"
 // @Router       /plans [get]
-// @Success      200  {object}  models.JSONResponseModel{data=[]entity.Plans}
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.PlanResponse}
 // @Failure      400  {object}  models.JSONResponseModel
 func (h *PlansHandler) GetPlans(ctx *fiber.Ctx) error {
-	plans, err := h.plansUsecase.GetPlans()
+	plans, err := h.plansUsecase.GetPlans(ctx)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
-				Message: "Invalid Request",
-				Errors:  err.Error(),
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
 			},
 		)
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(
 		models.JSONResponseModel{
-			Message: "Plans retrieved successfully",
+			Message: i18n.Message(ctx, "Plans retrieved successfully"),
 			Data:    plans,
 		},
 	)
@@ -96,3 +110,312 @@ func (h *PlansHandler) UpdatePlan(ctx *fiber.Ctx) error {
 		},
 	)
 }
+
+// @Tags         Plans
+// @Summary      Get All Meal Plans Including Archived
+// @Accept       json
+// @Produce      json
+// @Router       /plans/all [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.PlanResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) GetAllPlans(ctx *fiber.Ctx) error {
+	plans, err := h.plansUsecase.GetAllPlans(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plans retrieved successfully",
+			Data:    plans,
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Create a Plan
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.CreatePlansRequest  true  "Request body"
+// @Router       /plans [post]
+// @Security     BearerAuth
+// @Success      201  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) CreatePlan(ctx *fiber.Ctx) error {
+	var req dto.CreatePlansRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.plansUsecase.CreatePlan(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to create plan",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(
+		models.JSONResponseModel{
+			Message: "Plan created successfully",
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Reorder Plans
+// @Description  Listed plans are displayed first in the given order, the others keep their order after them.
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.ReorderPlansRequest  true  "Request body"
+// @Router       /plans/order [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) ReorderPlans(ctx *fiber.Ctx) error {
+	var req dto.ReorderPlansRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.plansUsecase.ReorderPlans(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to reorder plans",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plans reordered successfully",
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Archive a Plan
+// @Description  Hides the plan from the catalogue and blocks new subscriptions, existing subscriptions are kept.
+// @Accept       json
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Router       /plans/{plansId}/archive [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) ArchivePlan(ctx *fiber.Ctx) error {
+	if err := h.plansUsecase.ArchivePlan(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to archive plan",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plan archived successfully",
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Unarchive a Plan
+// @Accept       json
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Router       /plans/{plansId}/unarchive [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) UnarchivePlan(ctx *fiber.Ctx) error {
+	if err := h.plansUsecase.UnarchivePlan(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to unarchive plan",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plan unarchived successfully",
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Upload Plan Image
+// @Description  Accepts a JPEG, PNG or WebP image up to 2 MB and replaces the current image.
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Param        image formData file true "Plan image"
+// @Router       /plans/{plansId}/image [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.PlanResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) UploadPlanImage(ctx *fiber.Ctx) error {
+	file, err := ctx.FormFile("image")
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Image is required",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	plan, err := h.plansUsecase.UploadPlanImage(ctx, file)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to upload plan image",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plan image uploaded successfully",
+			Data:    plan,
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Delete Plan Image
+// @Accept       json
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Router       /plans/{plansId}/image [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) DeletePlanImage(ctx *fiber.Ctx) error {
+	if err := h.plansUsecase.DeletePlanImage(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete plan image",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plan image deleted successfully",
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Translate a Plan
+// @Description  Creates or replaces the name, slogan and features of a plan in a locale other than the default one.
+// @Accept       json
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Param        locale path string true "Locale (id)"
+// @Param        request  body  dto.PlanTranslationRequest  true  "Request body"
+// @Router       /plans/{plansId}/translations/{locale} [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) SavePlanTranslation(ctx *fiber.Ctx) error {
+	var req dto.PlanTranslationRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.plansUsecase.SavePlanTranslation(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to save plan translation",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plan translation saved successfully",
+		},
+	)
+}
+
+// @Tags         Plans
+// @Summary      Delete a Plan Translation
+// @Accept       json
+// @Produce      json
+// @Param        plansId path string true "Plans ID"
+// @Param        locale path string true "Locale (id)"
+// @Router       /plans/{plansId}/translations/{locale} [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *PlansHandler) DeletePlanTranslation(ctx *fiber.Ctx) error {
+	if err := h.plansUsecase.DeletePlanTranslation(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete plan translation",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Plan translation deleted successfully",
+		},
+	)
+}
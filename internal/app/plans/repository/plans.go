@@ -1,14 +1,30 @@
 package repository
 
 import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PlansPostgreSQLItf interface {
-	GetPlans() ([]entity.Plans, error)
+	GetPlans(includeArchived bool) ([]entity.Plans, error)
+	CreatePlan(plan entity.Plans, features []string, tags []string) error
 	UpdatePlan(plan entity.Plans) error
 	GetSpecificPlans(plans entity.Plans) (entity.Plans, error)
+	SetPlanAvailability(planId string, available bool) error
+	SetPlanArchived(planId string, archived bool) error
+	ReorderPlans(planIds []string) error
+	ReplacePlanFeatures(planId string, locale string, features []string) error
+	SavePlanTranslation(translation entity.PlanTranslation, features []string) error
+	DeletePlanTranslation(planId string, locale string) error
+	ReplacePlanTags(planId string, tags []string) error
+	UpdatePlanNutrition(planId string, nutrition entity.PlanNutrition) error
+	UpdatePlanImage(planId string, imageURL string, imageKey string) error
 }
 
 type PlansPostgreSQL struct {
@@ -19,29 +35,176 @@ func NewPlansPostgreSQL(db *gorm.DB) PlansPostgreSQLItf {
 	return &PlansPostgreSQL{db}
 }
 
-func (r *PlansPostgreSQL) GetPlans() ([]entity.Plans, error) {
+func (r *PlansPostgreSQL) GetPlans(includeArchived bool) ([]entity.Plans, error) {
 	var plans []entity.Plans
-	if err := r.db.Find(&plans).Error; err != nil {
+
+	query := r.db.Preload("Features", orderFeatures).Preload("Tags").Preload("Translations").Order("display_order, created_at")
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	if err := query.Find(&plans).Error; err != nil {
 		return nil, err
 	}
 	return plans, nil
 }
 
 func (r *PlansPostgreSQL) GetSpecificPlans(plans entity.Plans) (entity.Plans, error) {
-	if err := r.db.First(&plans).Error; err != nil {
+	if err := r.db.Preload("Features", orderFeatures).Preload("Tags").Preload("Translations").First(&plans).Error; err != nil {
 		return entity.Plans{}, err
 	}
 	return plans, nil
 }
 
+func (r *PlansPostgreSQL) CreatePlan(plan entity.Plans, features []string, tags []string) error {
+	plan.Features = newPlanFeatures(plan.ID, constant.DefaultLocale, features)
+	plan.Tags = newPlanTags(plan.ID, tags)
+
+	return r.db.Create(&plan).Error
+}
+
 func (r *PlansPostgreSQL) UpdatePlan(plan entity.Plans) error {
 	if plan.ID == "" {
 		return gorm.ErrRecordNotFound
 	}
 
-	if err := r.db.Updates(&plan).Error; err != nil {
+	if err := r.db.Omit("Features", "Tags").Updates(&plan).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (r *PlansPostgreSQL) SetPlanAvailability(planId string, available bool) error {
+	return r.db.Model(entity.Plans{}).Where("id = ?", planId).Update("is_available", available).Error
+}
+
+func (r *PlansPostgreSQL) SetPlanArchived(planId string, archived bool) error {
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+	}
+
+	return r.db.Model(entity.Plans{}).Where("id = ?", planId).Update("archived_at", archivedAt).Error
+}
+
+func (r *PlansPostgreSQL) ReorderPlans(planIds []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, planId := range planIds {
+			err := tx.Model(entity.Plans{}).Where("id = ?", planId).Update("display_order", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *PlansPostgreSQL) ReplacePlanFeatures(planId string, locale string, features []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replacePlanFeatures(tx, planId, locale, features)
+	})
+}
+
+// SavePlanTranslation creates or replaces the translation of a plan in one locale, features included
+func (r *PlansPostgreSQL) SavePlanTranslation(translation entity.PlanTranslation, features []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "plan_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "slogan", "updated_at"}),
+		}).Create(&translation).Error
+		if err != nil {
+			return err
+		}
+
+		return replacePlanFeatures(tx, translation.PlanId, translation.Locale, features)
+	})
+}
+
+func (r *PlansPostgreSQL) DeletePlanTranslation(planId string, locale string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("plan_id = ? AND locale = ?", planId, locale).Delete(&entity.PlanTranslation{}).Error
+		if err != nil {
+			return err
+		}
+
+		return replacePlanFeatures(tx, planId, locale, nil)
+	})
+}
+
+func (r *PlansPostgreSQL) ReplacePlanTags(planId string, tags []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("plan_id = ?", planId).Delete(&entity.PlanTag{}).Error; err != nil {
+			return err
+		}
+
+		if len(tags) == 0 {
+			return nil
+		}
+
+		return tx.Create(newPlanTags(planId, tags)).Error
+	})
+}
+
+func (r *PlansPostgreSQL) UpdatePlanNutrition(planId string, nutrition entity.PlanNutrition) error {
+	return r.db.Model(entity.Plans{}).Where("id = ?", planId).Updates(map[string]any{
+		"nutrition_calories_min":  nutrition.CaloriesMin,
+		"nutrition_calories_max":  nutrition.CaloriesMax,
+		"nutrition_protein_grams": nutrition.ProteinGrams,
+		"nutrition_carbs_grams":   nutrition.CarbsGrams,
+		"nutrition_fat_grams":     nutrition.FatGrams,
+	}).Error
+}
+
+func (r *PlansPostgreSQL) UpdatePlanImage(planId string, imageURL string, imageKey string) error {
+	return r.db.Model(entity.Plans{}).Where("id = ?", planId).Updates(map[string]any{
+		"image_url": imageURL,
+		"image_key": imageKey,
+	}).Error
+}
+
+func replacePlanFeatures(tx *gorm.DB, planId string, locale string, features []string) error {
+	if err := tx.Where("plan_id = ? AND locale = ?", planId, locale).Delete(&entity.PlanFeature{}).Error; err != nil {
+		return err
+	}
+
+	if len(features) == 0 {
+		return nil
+	}
+
+	return tx.Create(newPlanFeatures(planId, locale, features)).Error
+}
+
+func orderFeatures(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+func newPlanFeatures(planId string, locale string, features []string) []entity.PlanFeature {
+	result := make([]entity.PlanFeature, 0, len(features))
+	for i, text := range features {
+		result = append(result, entity.PlanFeature{
+			ID:       uuid.New(),
+			PlanId:   planId,
+			Locale:   locale,
+			Position: i + 1,
+			Text:     text,
+		})
+	}
+
+	return result
+}
+
+func newPlanTags(planId string, tags []string) []entity.PlanTag {
+	result := []entity.PlanTag{}
+	for _, tag := range tags {
+		if slices.ContainsFunc(result, func(t entity.PlanTag) bool { return t.Tag == tag }) {
+			continue
+		}
+
+		result = append(result, entity.PlanTag{PlanId: planId, Tag: tag})
+	}
+
+	return result
+}
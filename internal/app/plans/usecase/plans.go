@@ -1,32 +1,132 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
 	"github.com/jevvonn/sea-catering-be/internal/app/plans/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"github.com/jevvonn/sea-catering-be/internal/infra/i18n"
+	"github.com/jevvonn/sea-catering-be/internal/infra/storage"
+	"gorm.io/gorm"
 )
 
 type PlansUsecaseItf interface {
-	GetPlans() ([]entity.Plans, error)
+	GetPlans(ctx *fiber.Ctx) ([]dto.PlanResponse, error)
+	GetAllPlans(ctx *fiber.Ctx) ([]dto.PlanResponse, error)
+	CreatePlan(ctx *fiber.Ctx, req dto.CreatePlansRequest) error
 	UpdatePlan(ctx *fiber.Ctx, plan dto.UpdatePlansRequest) error
+	ArchivePlan(ctx *fiber.Ctx) error
+	UnarchivePlan(ctx *fiber.Ctx) error
+	ReorderPlans(ctx *fiber.Ctx, req dto.ReorderPlansRequest) error
+	UploadPlanImage(ctx *fiber.Ctx, file *multipart.FileHeader) (dto.PlanResponse, error)
+	DeletePlanImage(ctx *fiber.Ctx) error
+	SavePlanTranslation(ctx *fiber.Ctx, req dto.PlanTranslationRequest) error
+	DeletePlanTranslation(ctx *fiber.Ctx) error
 }
 
 type PlansUsecase struct {
 	plansRepo repository.PlansPostgreSQLItf
+	auditRepo auditRepo.AuditPostgreSQLItf
+	storage   storage.StorageService
 }
 
-func NewPlansUsecase(plansRepo repository.PlansPostgreSQLItf) PlansUsecaseItf {
-	return &PlansUsecase{plansRepo}
+func NewPlansUsecase(
+	plansRepo repository.PlansPostgreSQLItf,
+	auditRepo auditRepo.AuditPostgreSQLItf,
+	storage storage.StorageService,
+) PlansUsecaseItf {
+	return &PlansUsecase{plansRepo, auditRepo, storage}
 }
 
-func (u *PlansUsecase) GetPlans() ([]entity.Plans, error) {
-	plans, err := u.plansRepo.GetPlans()
+func (u *PlansUsecase) GetPlans(ctx *fiber.Ctx) ([]dto.PlanResponse, error) {
+	plans, err := u.plansRepo.GetPlans(false)
 	if err != nil {
 		return nil, err
 	}
 
-	return plans, nil
+	return toPlanResponses(plans, i18n.Locale(ctx)), nil
+}
+
+func (u *PlansUsecase) GetAllPlans(ctx *fiber.Ctx) ([]dto.PlanResponse, error) {
+	plans, err := u.plansRepo.GetPlans(true)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPlanResponses(plans, i18n.Locale(ctx))
+	for i, plan := range plans {
+		response[i].Translations = toPlanTranslationResponses(plan)
+	}
+
+	return response, nil
+}
+
+func (u *PlansUsecase) CreatePlan(ctx *fiber.Ctx, req dto.CreatePlansRequest) error {
+	_, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: req.ID})
+	if err == nil {
+		return errors.New("plan with this ID already exists")
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	plans, err := u.plansRepo.GetPlans(true)
+	if err != nil {
+		return err
+	}
+
+	// New plans are listed last
+	displayOrder := 1
+	for _, plan := range plans {
+		if plan.DisplayOrder >= displayOrder {
+			displayOrder = plan.DisplayOrder + 1
+		}
+	}
+
+	isAvailable := true
+	if req.IsAvailable != nil {
+		isAvailable = *req.IsAvailable
+	}
+
+	nutrition := entity.PlanNutrition{}
+	if req.Nutrition != nil {
+		nutrition = toPlanNutrition(*req.Nutrition)
+	}
+
+	err = u.plansRepo.CreatePlan(entity.Plans{
+		ID:           req.ID,
+		Name:         req.Name,
+		Slogan:       req.Slogan,
+		Price:        req.Price,
+		Nutrition:    nutrition,
+		DisplayOrder: displayOrder,
+		IsAvailable:  true,
+	}, req.Features, req.Tags)
+	if err != nil {
+		return err
+	}
+
+	// GORM skips false on create because the column defaults to true
+	if !isAvailable {
+		if err := u.plansRepo.SetPlanAvailability(req.ID, false); err != nil {
+			return err
+		}
+	}
+
+	return u.audit(ctx, constant.AuditActionPlanCreated, req.ID)
 }
 
 func (u *PlansUsecase) UpdatePlan(ctx *fiber.Ctx, plan dto.UpdatePlansRequest) error {
@@ -38,16 +138,337 @@ func (u *PlansUsecase) UpdatePlan(ctx *fiber.Ctx, plan dto.UpdatePlansRequest) e
 	}
 
 	updatedPlan := entity.Plans{
-		ID:       planID,
-		Name:     plan.Name,
-		Slogan:   plan.Slogan,
-		Price:    plan.Price,
-		Features: plan.Features,
+		ID:     planID,
+		Name:   plan.Name,
+		Slogan: plan.Slogan,
+		Price:  plan.Price,
 	}
 
 	if err := u.plansRepo.UpdatePlan(updatedPlan); err != nil {
 		return err
 	}
 
+	if len(plan.Features) > 0 {
+		if err := u.plansRepo.ReplacePlanFeatures(planID, constant.DefaultLocale, plan.Features); err != nil {
+			return err
+		}
+	}
+
+	if plan.Tags != nil {
+		if err := u.plansRepo.ReplacePlanTags(planID, plan.Tags); err != nil {
+			return err
+		}
+	}
+
+	if plan.Nutrition != nil {
+		if err := u.plansRepo.UpdatePlanNutrition(planID, toPlanNutrition(*plan.Nutrition)); err != nil {
+			return err
+		}
+	}
+
+	if plan.IsAvailable != nil {
+		if err := u.plansRepo.SetPlanAvailability(planID, *plan.IsAvailable); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func (u *PlansUsecase) ArchivePlan(ctx *fiber.Ctx) error {
+	planID := ctx.Params("id")
+
+	plan, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: planID})
+	if err != nil {
+		return err
+	}
+
+	if plan.ArchivedAt != nil {
+		return errors.New("plan is already archived")
+	}
+
+	if err := u.plansRepo.SetPlanArchived(planID, true); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionPlanArchived, planID)
+}
+
+func (u *PlansUsecase) UnarchivePlan(ctx *fiber.Ctx) error {
+	planID := ctx.Params("id")
+
+	plan, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: planID})
+	if err != nil {
+		return err
+	}
+
+	if plan.ArchivedAt == nil {
+		return errors.New("plan is not archived")
+	}
+
+	if err := u.plansRepo.SetPlanArchived(planID, false); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionPlanUnarchived, planID)
+}
+
+func (u *PlansUsecase) ReorderPlans(ctx *fiber.Ctx, req dto.ReorderPlansRequest) error {
+	plans, err := u.plansRepo.GetPlans(true)
+	if err != nil {
+		return err
+	}
+
+	order := []string{}
+	for _, planId := range req.PlanIds {
+		if slices.Contains(order, planId) {
+			return errors.New("duplicate plan " + planId)
+		}
+
+		found := slices.ContainsFunc(plans, func(p entity.Plans) bool {
+			return p.ID == planId
+		})
+		if !found {
+			return errors.New("plan " + planId + " not found")
+		}
+
+		order = append(order, planId)
+	}
+
+	// Plans missing from the request keep their relative order after the listed ones
+	for _, plan := range plans {
+		if !slices.Contains(order, plan.ID) {
+			order = append(order, plan.ID)
+		}
+	}
+
+	return u.plansRepo.ReorderPlans(order)
+}
+
+func (u *PlansUsecase) UploadPlanImage(ctx *fiber.Ctx, file *multipart.FileHeader) (dto.PlanResponse, error) {
+	planID := ctx.Params("id")
+
+	plan, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: planID})
+	if err != nil {
+		return dto.PlanResponse{}, err
+	}
+
+	if file.Size > constant.PlanImageMaxSize {
+		return dto.PlanResponse{}, fmt.Errorf("image must not be larger than %d MB", constant.PlanImageMaxSize/1024/1024)
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return dto.PlanResponse{}, err
+	}
+	defer content.Close()
+
+	// Detect the type from the content instead of trusting the client
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return dto.PlanResponse{}, err
+	}
+
+	extension, ok := constant.PlanImageExtensions[http.DetectContentType(head[:n])]
+	if !ok {
+		return dto.PlanResponse{}, errors.New("image must be a JPEG, PNG or WebP file")
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return dto.PlanResponse{}, err
+	}
+
+	key := fmt.Sprintf("plans/%s-%s%s", planID, uuid.NewString(), extension)
+	imageURL, err := u.storage.Save(key, content)
+	if err != nil {
+		return dto.PlanResponse{}, err
+	}
+
+	if err := u.plansRepo.UpdatePlanImage(planID, imageURL, key); err != nil {
+		u.storage.Delete(key)
+		return dto.PlanResponse{}, err
+	}
+
+	if plan.ImageKey != "" {
+		if err := u.storage.Delete(plan.ImageKey); err != nil {
+			log.Printf("failed to delete old image %s of plan %s: %v", plan.ImageKey, planID, err)
+		}
+	}
+
+	plan.ImageURL = imageURL
+	plan.ImageKey = key
+
+	return toPlanResponse(plan, i18n.Locale(ctx)), nil
+}
+
+func (u *PlansUsecase) DeletePlanImage(ctx *fiber.Ctx) error {
+	planID := ctx.Params("id")
+
+	plan, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: planID})
+	if err != nil {
+		return err
+	}
+
+	if plan.ImageKey == "" {
+		return errors.New("plan has no image")
+	}
+
+	if err := u.plansRepo.UpdatePlanImage(planID, "", ""); err != nil {
+		return err
+	}
+
+	return u.storage.Delete(plan.ImageKey)
+}
+
+func (u *PlansUsecase) SavePlanTranslation(ctx *fiber.Ctx, req dto.PlanTranslationRequest) error {
+	planID := ctx.Params("id")
+
+	locale, err := translationLocale(ctx.Params("locale"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: planID}); err != nil {
+		return err
+	}
+
+	return u.plansRepo.SavePlanTranslation(entity.PlanTranslation{
+		PlanId: planID,
+		Locale: locale,
+		Name:   req.Name,
+		Slogan: req.Slogan,
+	}, req.Features)
+}
+
+func (u *PlansUsecase) DeletePlanTranslation(ctx *fiber.Ctx) error {
+	planID := ctx.Params("id")
+
+	locale, err := translationLocale(ctx.Params("locale"))
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: planID}); err != nil {
+		return err
+	}
+
+	return u.plansRepo.DeletePlanTranslation(planID, locale)
+}
+
+// translationLocale checks the locale is supported and not the default one,
+// whose content is edited on the plan itself
+func translationLocale(locale string) (string, error) {
+	if !slices.Contains(constant.Locales, locale) {
+		return "", fmt.Errorf("unsupported locale, expected one of %s", strings.Join(constant.Locales, ", "))
+	}
+
+	if locale == constant.DefaultLocale {
+		return "", errors.New("content in the default locale is updated on the plan itself")
+	}
+
+	return locale, nil
+}
+
+func (u *PlansUsecase) audit(ctx *fiber.Ctx, action string, planId string) error {
+	actorId := uuid.MustParse(ctx.Locals("userId").(string))
+
+	return u.auditRepo.CreateAuditLog(entity.AuditLog{
+		ActorID:    &actorId,
+		Action:     action,
+		TargetType: "PLAN",
+		TargetID:   planId,
+		IPAddress:  ctx.IP(),
+	})
+}
+
+func toPlanNutrition(nutrition dto.PlanNutrition) entity.PlanNutrition {
+	return entity.PlanNutrition{
+		CaloriesMin:  nutrition.CaloriesMin,
+		CaloriesMax:  nutrition.CaloriesMax,
+		ProteinGrams: nutrition.ProteinGrams,
+		CarbsGrams:   nutrition.CarbsGrams,
+		FatGrams:     nutrition.FatGrams,
+	}
+}
+
+// toPlanResponse returns the plan content in the given locale, falling back to
+// the default locale when the plan has no translation
+func toPlanResponse(plan entity.Plans, locale string) dto.PlanResponse {
+	name, slogan := plan.Name, plan.Slogan
+	features := planFeatures(plan, locale)
+
+	translation := slices.IndexFunc(plan.Translations, func(t entity.PlanTranslation) bool {
+		return t.Locale == locale
+	})
+	if translation == -1 || len(features) == 0 {
+		locale = constant.DefaultLocale
+		features = planFeatures(plan, locale)
+	} else {
+		name = plan.Translations[translation].Name
+		slogan = plan.Translations[translation].Slogan
+	}
+
+	tags := []string{}
+	for _, tag := range plan.Tags {
+		tags = append(tags, tag.Tag)
+	}
+	slices.Sort(tags)
+
+	return dto.PlanResponse{
+		ID:       plan.ID,
+		Locale:   locale,
+		Name:     name,
+		Slogan:   slogan,
+		Price:    plan.Price,
+		Features: features,
+		Tags:     tags,
+		Nutrition: dto.PlanNutrition{
+			CaloriesMin:  plan.Nutrition.CaloriesMin,
+			CaloriesMax:  plan.Nutrition.CaloriesMax,
+			ProteinGrams: plan.Nutrition.ProteinGrams,
+			CarbsGrams:   plan.Nutrition.CarbsGrams,
+			FatGrams:     plan.Nutrition.FatGrams,
+		},
+		ImageURL:     plan.ImageURL,
+		DisplayOrder: plan.DisplayOrder,
+		IsAvailable:  plan.IsAvailable,
+		ArchivedAt:   plan.ArchivedAt,
+		CreatedAt:    plan.CreatedAt,
+		UpdatedAt:    plan.UpdatedAt,
+	}
+}
+
+func toPlanResponses(plans []entity.Plans, locale string) []dto.PlanResponse {
+	response := []dto.PlanResponse{}
+	for _, plan := range plans {
+		response = append(response, toPlanResponse(plan, locale))
+	}
+
+	return response
+}
+
+func toPlanTranslationResponses(plan entity.Plans) []dto.PlanTranslationResponse {
+	response := []dto.PlanTranslationResponse{}
+	for _, translation := range plan.Translations {
+		response = append(response, dto.PlanTranslationResponse{
+			Locale:   translation.Locale,
+			Name:     translation.Name,
+			Slogan:   translation.Slogan,
+			Features: planFeatures(plan, translation.Locale),
+		})
+	}
+
+	return response
+}
+
+func planFeatures(plan entity.Plans, locale string) []string {
+	features := []string{}
+	for _, feature := range plan.Features {
+		if feature.Locale == locale {
+			features = append(features, feature.Text)
+		}
+	}
+
+	return features
+}
@@ -0,0 +1,286 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/report/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
+	"github.com/jevvonn/sea-catering-be/internal/models"
+)
+
+type ReportHandler struct {
+	reportUsecase usecase.ReportUsecaseItf
+	validator     validator.ValidationService
+}
+
+func NewReportHandler(
+	router fiber.Router,
+	mw *middleware.Middleware,
+	reportUsecase usecase.ReportUsecaseItf,
+	validator validator.ValidationService,
+) {
+	handler := ReportHandler{reportUsecase, validator}
+
+	router.Get("/reports/mrr", mw.Authenticated, mw.RequirePermission(constant.PermissionReportsRead), handler.GetMRRReport)
+	router.Get("/reports/cohorts", mw.Authenticated, mw.RequirePermission(constant.PermissionReportsRead), handler.GetCohortReport)
+	router.Get("/reports/churn-reasons", mw.Authenticated, mw.RequirePermission(constant.PermissionReportsRead), handler.GetChurnReasons)
+	router.Get("/reports/timeseries", mw.Authenticated, mw.RequirePermission(constant.PermissionReportsRead), handler.GetTimeseries)
+}
+
+// @Tags         Report
+// @Summary      Get Monthly Recurring Revenue Report
+// @Description  MRR, new, reactivated and churned MRR, net growth and churn rates per month, computed from the subscription status history.
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        start_month query string false "Start month (mm-yyyy), defaults to 11 months before the end month"
+// @Param        end_month query string false "End month (mm-yyyy), defaults to the current month"
+// @Param        format query string false "json (default), csv or xlsx"
+// @Router       /reports/mrr [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.MRRReportResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *ReportHandler) GetMRRReport(ctx *fiber.Ctx) error {
+	var req dto.GetMRRReportQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.reportUsecase.ExportMRRReport(ctx, req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to get MRR report",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "mrr", stream)
+	}
+
+	report, err := h.reportUsecase.GetMRRReport(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get MRR report",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "MRR report retrieved successfully",
+			Data:    report,
+		},
+	)
+}
+
+// @Tags         Report
+// @Summary      Get Subscription Time Series
+// @Description  Bucketed series of new subscriptions, cancellations, active subscriptions or revenue (MRR), one series per plan or meal type when grouped. Active subscriptions and revenue are taken at the end of each bucket. Subscriptions count towards each of their meal types, with their revenue split between them.
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        metric query string true "Metric (new_subscriptions, cancellations, active_subscriptions, revenue)"
+// @Param        interval query string false "Bucket size (day, week, month), defaults to day"
+// @Param        group_by query string false "Group by (plan, mealtype)"
+// @Param        start_date query string false "Start date (dd-mm-yyyy)"
+// @Param        end_date query string false "End date (dd-mm-yyyy), defaults to today"
+// @Param        format query string false "json (default), csv or xlsx"
+// @Router       /reports/timeseries [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.TimeseriesResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *ReportHandler) GetTimeseries(ctx *fiber.Ctx) error {
+	var req dto.GetTimeseriesQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.reportUsecase.ExportTimeseries(ctx, req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to get time series",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "timeseries", stream)
+	}
+
+	report, err := h.reportUsecase.GetTimeseries(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get time series",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Time series retrieved successfully",
+			Data:    report,
+		},
+	)
+}
+
+// @Tags         Report
+// @Summary      Get Cohort Retention Report
+// @Description  Groups users by the month of their first subscription and shows the percentage still subscribed 1 to 12 months later. With plan_id only subscriptions to that plan count. Returned as JSON, or as a CSV or XLSX file with the format parameter.
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        start_month query string false "First cohort (mm-yyyy), defaults to 11 months before the end month"
+// @Param        end_month query string false "Last cohort (mm-yyyy), defaults to the current month"
+// @Param        plan_id query string false "Plan ID"
+// @Param        format query string false "json (default), csv or xlsx"
+// @Router       /reports/cohorts [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.CohortReportResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *ReportHandler) GetCohortReport(ctx *fiber.Ctx) error {
+	var req dto.GetCohortReportQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.reportUsecase.ExportCohortReport(ctx, req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to get cohort report",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "cohort-retention", stream)
+	}
+
+	report, err := h.reportUsecase.GetCohortReport(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get cohort report",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Cohort report retrieved successfully",
+			Data:    report,
+		},
+	)
+}
+
+// @Tags         Report
+// @Summary      Get Churn Reasons Report
+// @Description  Cancellation requests per reason with the churned MRR, the retention offers made and how many customers accepted them, plus the latest free text notes. Requests count in the month they were made, cancellations at the end of the billing period included.
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        start_month query string false "Start month (mm-yyyy), defaults to 11 months before the end month"
+// @Param        end_month query string false "End month (mm-yyyy), defaults to the current month"
+// @Param        format query string false "json (default), csv or xlsx"
+// @Router       /reports/churn-reasons [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.ChurnReasonsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *ReportHandler) GetChurnReasons(ctx *fiber.Ctx) error {
+	var req dto.GetChurnReasonsQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.reportUsecase.ExportChurnReasons(ctx, req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to get churn reasons report",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "churn-reasons", stream)
+	}
+
+	report, err := h.reportUsecase.GetChurnReasons(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get churn reasons report",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Churn reasons report retrieved successfully",
+			Data:    report,
+		},
+	)
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"gorm.io/gorm"
+)
+
+type ReportPostgreSQLItf interface {
+	GetMRRMonths(from time.Time, to time.Time) ([]dto.MRRMonth, error)
+	GetEventTimeseries(eventType string, interval string, groupBy string, from time.Time, to time.Time) ([]dto.TimeseriesValue, error)
+	GetActiveTimeseries(revenue bool, interval string, groupBy string, from time.Time, to time.Time) ([]dto.TimeseriesValue, error)
+	GetCohortRetention(from time.Time, to time.Time, planId string, months int) ([]dto.CohortRetentionCount, error)
+	GetChurnReasons(from time.Time, to time.Time) ([]dto.ChurnReason, error)
+	GetChurnNotes(from time.Time, to time.Time, limit int) ([]dto.ChurnNote, error)
+}
+
+type ReportPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewReportPostgreSQL(db *gorm.DB) ReportPostgreSQLItf {
+	return &ReportPostgreSQL{db}
+}
+
+// mrrMonthsQuery replays subscription_events per month. A subscription counts towards
+// the MRR at a moment when its last event before that moment is not a cancellation, with
+// the MRR of that event. MRR changes are compared with the previous event of the subscription.
+const mrrMonthsQuery = `
+WITH months AS (
+	SELECT month::date AS month_start, (month + interval '1 month')::date AS month_end
+	FROM generate_series(@from::date, @to::date, interval '1 month') AS month
+)
+SELECT
+	m.month_start,
+	opening.mrr AS starting_mrr,
+	opening.subscriptions AS starting_subscriptions,
+	closing.mrr AS ending_mrr,
+	closing.subscriptions AS ending_subscriptions,
+	moves.new_mrr,
+	moves.new_subscriptions,
+	moves.reactivation_mrr,
+	moves.reactivations,
+	moves.expansion_mrr,
+	moves.contraction_mrr,
+	moves.churned_mrr,
+	moves.churned_subscriptions
+FROM months m
+CROSS JOIN LATERAL (
+	SELECT COALESCE(SUM(e.mrr), 0) AS mrr, COUNT(*) AS subscriptions
+	FROM (
+		SELECT DISTINCT ON (subscription_id) type, mrr
+		FROM subscription_events
+		WHERE occurred_at < m.month_start
+		ORDER BY subscription_id, occurred_at DESC, created_at DESC
+	) e
+	WHERE e.type <> @cancelled
+) opening
+CROSS JOIN LATERAL (
+	SELECT COALESCE(SUM(e.mrr), 0) AS mrr, COUNT(*) AS subscriptions
+	FROM (
+		SELECT DISTINCT ON (subscription_id) type, mrr
+		FROM subscription_events
+		WHERE occurred_at < m.month_end
+		ORDER BY subscription_id, occurred_at DESC, created_at DESC
+	) e
+	WHERE e.type <> @cancelled
+) closing
+CROSS JOIN LATERAL (
+	SELECT
+		COALESCE(SUM(mrr) FILTER (WHERE type = @created), 0) AS new_mrr,
+		COUNT(*) FILTER (WHERE type = @created) AS new_subscriptions,
+		COALESCE(SUM(mrr) FILTER (WHERE type = @reactivated), 0) AS reactivation_mrr,
+		COUNT(*) FILTER (WHERE type = @reactivated) AS reactivations,
+		COALESCE(SUM(mrr - previous_mrr) FILTER (WHERE type = @changed AND mrr > previous_mrr), 0) AS expansion_mrr,
+		COALESCE(SUM(previous_mrr - mrr) FILTER (WHERE type = @changed AND mrr < previous_mrr), 0) AS contraction_mrr,
+		COALESCE(SUM(mrr) FILTER (WHERE type = @cancelled), 0) AS churned_mrr,
+		COUNT(*) FILTER (WHERE type = @cancelled) AS churned_subscriptions
+	FROM (
+		SELECT type, mrr, occurred_at,
+			LAG(mrr) OVER (PARTITION BY subscription_id ORDER BY occurred_at, created_at) AS previous_mrr
+		FROM subscription_events
+		WHERE occurred_at < m.month_end
+	) e
+	WHERE occurred_at >= m.month_start
+) moves
+ORDER BY m.month_start`
+
+// GetMRRMonths returns the revenue movement of every month from the month of from to the month of to
+func (r *ReportPostgreSQL) GetMRRMonths(from time.Time, to time.Time) ([]dto.MRRMonth, error) {
+	var months []dto.MRRMonth
+	err := r.db.Raw(mrrMonthsQuery, map[string]any{
+		"from":        from.Format("2006-01-02"),
+		"to":          to.Format("2006-01-02"),
+		"created":     constant.SubscriptionEventCreated,
+		"cancelled":   constant.SubscriptionEventCancelled,
+		"reactivated": constant.SubscriptionEventReactivated,
+		"changed":     constant.SubscriptionEventMRRChanged,
+	}).Scan(&months).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return months, nil
+}
+
+// timeseriesGroups are the lateral subqueries splitting a subscription s into report
+// groups. Weight spreads its revenue over the groups, a subscription priced for
+// several meal types counts towards each of them with its share.
+var timeseriesGroups = map[string]string{
+	"":         "SELECT 'all'::text, 1::numeric",
+	"plan":     "SELECT p.name::text, 1::numeric FROM plans p WHERE p.id = s.plan_id",
+	"mealtype": "SELECT TRIM(m), 1.0 / cardinality(string_to_array(s.mealtypes, ',')) FROM unnest(string_to_array(s.mealtypes, ',')) AS m",
+}
+
+var timeseriesSteps = map[string]string{
+	"day":   "1 day",
+	"week":  "1 week",
+	"month": "1 month",
+}
+
+// GetEventTimeseries counts the events of a type per bucket and group. The range is
+// expected to start at the beginning of a bucket.
+func (r *ReportPostgreSQL) GetEventTimeseries(eventType string, interval string, groupBy string, from time.Time, to time.Time) ([]dto.TimeseriesValue, error) {
+	query := `
+SELECT date_trunc(@interval, e.occurred_at)::date AS bucket, g.group_key, COUNT(*) AS value
+FROM subscription_events e
+JOIN subscriptions s ON s.id = e.subscription_id
+CROSS JOIN LATERAL (` + timeseriesGroups[groupBy] + `) AS g(group_key, weight)
+WHERE e.type = @type AND e.occurred_at >= @from::date AND e.occurred_at < @to::date + 1
+GROUP BY bucket, g.group_key
+ORDER BY bucket, g.group_key`
+
+	var values []dto.TimeseriesValue
+	err := r.db.Raw(query, map[string]any{
+		"interval": interval,
+		"type":     eventType,
+		"from":     from.Format("2006-01-02"),
+		"to":       to.Format("2006-01-02"),
+	}).Scan(&values).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// GetActiveTimeseries counts the active subscriptions, or sums their MRR, at the end of
+// every bucket per group. The range is expected to start at the beginning of a bucket.
+func (r *ReportPostgreSQL) GetActiveTimeseries(revenue bool, interval string, groupBy string, from time.Time, to time.Time) ([]dto.TimeseriesValue, error) {
+	value := "COUNT(*)"
+	if revenue {
+		value = "ROUND(SUM(latest.mrr * g.weight), 2)"
+	}
+
+	query := `
+SELECT b.bucket::date AS bucket, g.group_key, ` + value + ` AS value
+FROM generate_series(@from::date, @to::date, @step::interval) AS b(bucket)
+CROSS JOIN LATERAL (
+	SELECT DISTINCT ON (e.subscription_id) e.subscription_id, e.type, e.mrr
+	FROM subscription_events e
+	WHERE e.occurred_at < LEAST(b.bucket + @step::interval, @to::date + 1)
+	ORDER BY e.subscription_id, e.occurred_at DESC, e.created_at DESC
+) AS latest
+JOIN subscriptions s ON s.id = latest.subscription_id
+CROSS JOIN LATERAL (` + timeseriesGroups[groupBy] + `) AS g(group_key, weight)
+WHERE latest.type <> @cancelled
+GROUP BY b.bucket, g.group_key
+ORDER BY b.bucket, g.group_key`
+
+	var values []dto.TimeseriesValue
+	err := r.db.Raw(query, map[string]any{
+		"step":      timeseriesSteps[interval],
+		"from":      from.Format("2006-01-02"),
+		"to":        to.Format("2006-01-02"),
+		"cancelled": constant.SubscriptionEventCancelled,
+	}).Scan(&values).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// cohortRetentionQuery groups users by the month of their first subscription, of a plan
+// when one is given. A user is retained k months later when one of those subscriptions
+// was created before and not cancelled by then. Month 0 holds the cohort size.
+const cohortRetentionQuery = `
+WITH subs AS (
+	SELECT user_id, created_at, cancelled_at
+	FROM subscriptions
+	WHERE @plan = '' OR plan_id = @plan
+),
+cohorts AS (
+	SELECT user_id, MIN(created_at) AS first_at, date_trunc('month', MIN(created_at))::date AS cohort_month
+	FROM subs
+	GROUP BY user_id
+)
+SELECT cohort_month, 0 AS month_offset, COUNT(*) AS eligible_users, COUNT(*) AS active_users
+FROM cohorts
+WHERE cohort_month BETWEEN @from::date AND @to::date
+GROUP BY cohort_month
+UNION ALL
+SELECT
+	c.cohort_month,
+	k.month_offset,
+	COUNT(*) AS eligible_users,
+	COUNT(*) FILTER (WHERE EXISTS (
+		SELECT 1 FROM subs s
+		WHERE s.user_id = c.user_id
+			AND s.created_at <= c.first_at + make_interval(months => k.month_offset)
+			AND (s.cancelled_at IS NULL OR s.cancelled_at > c.first_at + make_interval(months => k.month_offset))
+	)) AS active_users
+FROM cohorts c
+CROSS JOIN generate_series(1, @months) AS k(month_offset)
+WHERE c.cohort_month BETWEEN @from::date AND @to::date
+	AND c.first_at + make_interval(months => k.month_offset) <= NOW()
+GROUP BY c.cohort_month, k.month_offset
+ORDER BY cohort_month, month_offset`
+
+func (r *ReportPostgreSQL) GetCohortRetention(from time.Time, to time.Time, planId string, months int) ([]dto.CohortRetentionCount, error) {
+	var counts []dto.CohortRetentionCount
+	err := r.db.Raw(cohortRetentionQuery, map[string]any{
+		"plan":   planId,
+		"from":   from.Format("2006-01-02"),
+		"to":     to.Format("2006-01-02"),
+		"months": months,
+	}).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetChurnReasons groups the cancellation requests made from from until before to by reason.
+// Revoked requests only count as offers made.
+func (r *ReportPostgreSQL) GetChurnReasons(from time.Time, to time.Time) ([]dto.ChurnReason, error) {
+	var reasons []dto.ChurnReason
+	err := r.db.Raw(
+		`SELECT reason,
+			COUNT(*) FILTER (WHERE outcome IN (@scheduled, @cancelled)) AS cancellations,
+			COALESCE(SUM(mrr) FILTER (WHERE outcome IN (@scheduled, @cancelled)), 0) AS churned_mrr,
+			COUNT(*) FILTER (WHERE offer_type <> '') AS offers_made,
+			COUNT(*) FILTER (WHERE outcome = @retained) AS retained
+		FROM subscription_cancellations
+		WHERE created_at >= @from::date AND created_at < @to::date
+		GROUP BY reason
+		ORDER BY cancellations DESC, reason`,
+		map[string]any{
+			"from":      from.Format("2006-01-02"),
+			"to":        to.Format("2006-01-02"),
+			"scheduled": constant.CancellationOutcomeScheduled,
+			"cancelled": constant.CancellationOutcomeCancelled,
+			"retained":  constant.CancellationOutcomeRetained,
+		},
+	).Scan(&reasons).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reasons, nil
+}
+
+// GetChurnNotes returns the latest cancellation requests that came with an explanation
+func (r *ReportPostgreSQL) GetChurnNotes(from time.Time, to time.Time, limit int) ([]dto.ChurnNote, error) {
+	var notes []dto.ChurnNote
+	err := r.db.Table("subscription_cancellations").
+		Select("subscription_id, reason, outcome, note, created_at").
+		Where("note <> '' AND created_at >= ?::date AND created_at < ?::date", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("created_at DESC").
+		Limit(limit).
+		Scan(&notes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
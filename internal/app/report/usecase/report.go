@@ -0,0 +1,441 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/report/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
+)
+
+// maxTimeseriesBuckets keeps a single report query bounded
+const (
+	maxTimeseriesBuckets = 366
+
+	// cohortRetentionMonths is how many months after the first subscription retention is followed
+	cohortRetentionMonths = 12
+
+	// churnNotesLimit is how many free text cancellation notes the churn reasons report lists
+	churnNotesLimit = 50
+)
+
+type ReportUsecaseItf interface {
+	GetMRRReport(ctx *fiber.Ctx, query dto.GetMRRReportQuery) (dto.MRRReportResponse, error)
+	GetTimeseries(ctx *fiber.Ctx, query dto.GetTimeseriesQuery) (dto.TimeseriesResponse, error)
+	GetCohortReport(ctx *fiber.Ctx, query dto.GetCohortReportQuery) (dto.CohortReportResponse, error)
+	ExportMRRReport(ctx *fiber.Ctx, query dto.GetMRRReportQuery) (export.StreamFunc, error)
+	ExportTimeseries(ctx *fiber.Ctx, query dto.GetTimeseriesQuery) (export.StreamFunc, error)
+	ExportCohortReport(ctx *fiber.Ctx, query dto.GetCohortReportQuery) (export.StreamFunc, error)
+	GetChurnReasons(ctx *fiber.Ctx, query dto.GetChurnReasonsQuery) (dto.ChurnReasonsResponse, error)
+	ExportChurnReasons(ctx *fiber.Ctx, query dto.GetChurnReasonsQuery) (export.StreamFunc, error)
+}
+
+type ReportUsecase struct {
+	reportRepo repository.ReportPostgreSQLItf
+}
+
+func NewReportUsecase(reportRepo repository.ReportPostgreSQLItf) ReportUsecaseItf {
+	return &ReportUsecase{reportRepo}
+}
+
+func (u *ReportUsecase) GetMRRReport(ctx *fiber.Ctx, query dto.GetMRRReportQuery) (dto.MRRReportResponse, error) {
+	startMonth, endMonth, err := utils.ParseMonthRange(query.StartMonth, query.EndMonth)
+	if err != nil {
+		return dto.MRRReportResponse{}, err
+	}
+
+	months, err := u.reportRepo.GetMRRMonths(startMonth, endMonth)
+	if err != nil {
+		return dto.MRRReportResponse{}, err
+	}
+
+	for i := range months {
+		month := &months[i]
+		month.Month = month.MonthStart.Format(utils.MonthLayout)
+		month.NetNewMRR = roundMoney(month.NewMRR + month.ReactivationMRR + month.ExpansionMRR - month.ContractionMRR - month.ChurnedMRR)
+		month.ChurnRate = percentage(float64(month.ChurnedSubscriptions), float64(month.StartingSubscriptions))
+		month.RevenueChurnRate = percentage(month.ChurnedMRR, month.StartingMRR)
+	}
+
+	currentMRR := 0.0
+	if len(months) > 0 {
+		currentMRR = months[len(months)-1].EndingMRR
+	}
+
+	return dto.MRRReportResponse{
+		StartMonth: startMonth.Format(utils.MonthLayout),
+		EndMonth:   endMonth.Format(utils.MonthLayout),
+		CurrentMRR: currentMRR,
+		Months:     months,
+	}, nil
+}
+
+func (u *ReportUsecase) GetTimeseries(ctx *fiber.Ctx, query dto.GetTimeseriesQuery) (dto.TimeseriesResponse, error) {
+	if query.Interval == "" {
+		query.Interval = "day"
+	}
+
+	startDate, endDate, err := parseTimeseriesRange(query.StartDate, query.EndDate, query.Interval)
+	if err != nil {
+		return dto.TimeseriesResponse{}, err
+	}
+
+	buckets := timeseriesBuckets(startDate, endDate, query.Interval)
+	if len(buckets) > maxTimeseriesBuckets {
+		return dto.TimeseriesResponse{}, fmt.Errorf("the range has %d buckets, use a longer interval or a shorter range", len(buckets))
+	}
+
+	var values []dto.TimeseriesValue
+	switch query.Metric {
+	case "new_subscriptions":
+		values, err = u.reportRepo.GetEventTimeseries(constant.SubscriptionEventCreated, query.Interval, query.GroupBy, startDate, endDate)
+	case "cancellations":
+		values, err = u.reportRepo.GetEventTimeseries(constant.SubscriptionEventCancelled, query.Interval, query.GroupBy, startDate, endDate)
+	case "active_subscriptions":
+		values, err = u.reportRepo.GetActiveTimeseries(false, query.Interval, query.GroupBy, startDate, endDate)
+	case "revenue":
+		values, err = u.reportRepo.GetActiveTimeseries(true, query.Interval, query.GroupBy, startDate, endDate)
+	}
+	if err != nil {
+		return dto.TimeseriesResponse{}, err
+	}
+
+	return dto.TimeseriesResponse{
+		Metric:    query.Metric,
+		Interval:  query.Interval,
+		GroupBy:   query.GroupBy,
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		Series:    toTimeseriesSeries(values, buckets),
+	}, nil
+}
+
+func (u *ReportUsecase) GetCohortReport(ctx *fiber.Ctx, query dto.GetCohortReportQuery) (dto.CohortReportResponse, error) {
+	startMonth, endMonth, err := utils.ParseMonthRange(query.StartMonth, query.EndMonth)
+	if err != nil {
+		return dto.CohortReportResponse{}, err
+	}
+
+	counts, err := u.reportRepo.GetCohortRetention(startMonth, endMonth, query.PlanId, cohortRetentionMonths)
+	if err != nil {
+		return dto.CohortReportResponse{}, err
+	}
+
+	cohorts := []dto.CohortResponse{}
+	for _, count := range counts {
+		if count.MonthOffset == 0 {
+			cohorts = append(cohorts, dto.CohortResponse{
+				Cohort:    count.CohortMonth.Format(utils.MonthLayout),
+				Users:     count.ActiveUsers,
+				Retention: make([]*float64, cohortRetentionMonths),
+			})
+			continue
+		}
+
+		// Rows are ordered by cohort, so retention belongs to the last cohort added
+		if len(cohorts) == 0 || count.MonthOffset > cohortRetentionMonths {
+			continue
+		}
+
+		retention := percentage(float64(count.ActiveUsers), float64(count.EligibleUsers))
+		cohorts[len(cohorts)-1].Retention[count.MonthOffset-1] = &retention
+	}
+
+	return dto.CohortReportResponse{
+		StartMonth: startMonth.Format(utils.MonthLayout),
+		EndMonth:   endMonth.Format(utils.MonthLayout),
+		PlanId:     query.PlanId,
+		Cohorts:    cohorts,
+	}, nil
+}
+
+// ExportMRRReport exports one row per month
+func (u *ReportUsecase) ExportMRRReport(ctx *fiber.Ctx, query dto.GetMRRReportQuery) (export.StreamFunc, error) {
+	report, err := u.GetMRRReport(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		err := w.WriteRow(
+			"month", "starting_mrr", "new_mrr", "reactivation_mrr", "expansion_mrr", "contraction_mrr", "churned_mrr", "net_new_mrr", "ending_mrr",
+			"starting_subscriptions", "new_subscriptions", "reactivations", "churned_subscriptions", "ending_subscriptions",
+			"churn_rate", "revenue_churn_rate",
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, month := range report.Months {
+			err := w.WriteRow(
+				month.Month, month.StartingMRR, month.NewMRR, month.ReactivationMRR, month.ExpansionMRR, month.ContractionMRR,
+				month.ChurnedMRR, month.NetNewMRR, month.EndingMRR,
+				month.StartingSubscriptions, month.NewSubscriptions, month.Reactivations, month.ChurnedSubscriptions, month.EndingSubscriptions,
+				month.ChurnRate, month.RevenueChurnRate,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// ExportTimeseries exports one row per group and bucket
+func (u *ReportUsecase) ExportTimeseries(ctx *fiber.Ctx, query dto.GetTimeseriesQuery) (export.StreamFunc, error) {
+	report, err := u.GetTimeseries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		if err := w.WriteRow("bucket", "group", report.Metric); err != nil {
+			return err
+		}
+
+		for _, series := range report.Series {
+			for _, point := range series.Points {
+				if err := w.WriteRow(point.Bucket, series.Group, point.Value); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// ExportCohortReport exports one row per cohort with a retention column per month,
+// months that have not been reached yet are left empty
+func (u *ReportUsecase) ExportCohortReport(ctx *fiber.Ctx, query dto.GetCohortReportQuery) (export.StreamFunc, error) {
+	report, err := u.GetCohortReport(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		header := []any{"cohort", "users"}
+		for month := 1; month <= cohortRetentionMonths; month++ {
+			header = append(header, fmt.Sprintf("month_%d", month))
+		}
+
+		if err := w.WriteRow(header...); err != nil {
+			return err
+		}
+
+		for _, cohort := range report.Cohorts {
+			row := []any{cohort.Cohort, cohort.Users}
+			for _, retention := range cohort.Retention {
+				row = append(row, retention)
+			}
+
+			if err := w.WriteRow(row...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+func (u *ReportUsecase) GetChurnReasons(ctx *fiber.Ctx, query dto.GetChurnReasonsQuery) (dto.ChurnReasonsResponse, error) {
+	startMonth, endMonth, err := utils.ParseMonthRange(query.StartMonth, query.EndMonth)
+	if err != nil {
+		return dto.ChurnReasonsResponse{}, err
+	}
+
+	reasons, err := u.reportRepo.GetChurnReasons(startMonth, endMonth.AddDate(0, 1, 0))
+	if err != nil {
+		return dto.ChurnReasonsResponse{}, err
+	}
+
+	notes, err := u.reportRepo.GetChurnNotes(startMonth, endMonth.AddDate(0, 1, 0), churnNotesLimit)
+	if err != nil {
+		return dto.ChurnReasonsResponse{}, err
+	}
+
+	report := dto.ChurnReasonsResponse{
+		StartMonth: startMonth.Format(utils.MonthLayout),
+		EndMonth:   endMonth.Format(utils.MonthLayout),
+		Reasons:    reasons,
+		Notes:      notes,
+	}
+
+	for _, reason := range reasons {
+		report.TotalCancellations += reason.Cancellations
+		report.TotalChurnedMRR += reason.ChurnedMRR
+		report.TotalRetained += reason.Retained
+	}
+	report.TotalChurnedMRR = roundMoney(report.TotalChurnedMRR)
+
+	for i := range report.Reasons {
+		reason := &report.Reasons[i]
+		reason.Share = percentage(float64(reason.Cancellations), float64(report.TotalCancellations))
+		reason.SaveRate = percentage(float64(reason.Retained), float64(reason.OffersMade))
+	}
+
+	if report.Reasons == nil {
+		report.Reasons = []dto.ChurnReason{}
+	}
+
+	if report.Notes == nil {
+		report.Notes = []dto.ChurnNote{}
+	}
+
+	return report, nil
+}
+
+// ExportChurnReasons exports one row per reason, the notes are left out
+func (u *ReportUsecase) ExportChurnReasons(ctx *fiber.Ctx, query dto.GetChurnReasonsQuery) (export.StreamFunc, error) {
+	report, err := u.GetChurnReasons(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		err := w.WriteRow("reason", "cancellations", "churned_mrr", "share", "offers_made", "retained", "save_rate")
+		if err != nil {
+			return err
+		}
+
+		for _, reason := range report.Reasons {
+			err := w.WriteRow(
+				reason.Reason, reason.Cancellations, reason.ChurnedMRR, reason.Share, reason.OffersMade, reason.Retained, reason.SaveRate,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// toTimeseriesSeries turns the values into one series per group with a point for every bucket,
+// buckets without a value are zero
+func toTimeseriesSeries(values []dto.TimeseriesValue, buckets []time.Time) []dto.TimeseriesSeries {
+	groups := []string{}
+	valuesByGroup := map[string]map[string]float64{}
+	for _, value := range values {
+		if _, ok := valuesByGroup[value.GroupKey]; !ok {
+			groups = append(groups, value.GroupKey)
+			valuesByGroup[value.GroupKey] = map[string]float64{}
+		}
+
+		valuesByGroup[value.GroupKey][value.Bucket.Format("2006-01-02")] = value.Value
+	}
+
+	sort.Strings(groups)
+
+	series := []dto.TimeseriesSeries{}
+	for _, group := range groups {
+		points := []dto.TimeseriesPoint{}
+		for _, bucket := range buckets {
+			key := bucket.Format("2006-01-02")
+			points = append(points, dto.TimeseriesPoint{
+				Bucket: key,
+				Value:  valuesByGroup[group][key],
+			})
+		}
+
+		series = append(series, dto.TimeseriesSeries{
+			Group:  group,
+			Points: points,
+		})
+	}
+
+	return series
+}
+
+// parseTimeseriesRange parses dd-mm-yyyy dates and moves the start back to the beginning
+// of its bucket. Without dates the last 30 days, 12 weeks or 12 months are reported.
+func parseTimeseriesRange(start string, end string, interval string) (time.Time, time.Time, error) {
+	parseLayout := "02-01-2006"
+	now := time.Now()
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if end != "" {
+		parsed, err := time.Parse(parseLayout, end)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid end date format, expected dd-mm-yyyy")
+		}
+		endDate = parsed
+	}
+
+	var startDate time.Time
+	switch interval {
+	case "week":
+		startDate = endDate.AddDate(0, 0, -7*11)
+	case "month":
+		startDate = endDate.AddDate(0, -11, 0)
+	default:
+		startDate = endDate.AddDate(0, 0, -29)
+	}
+
+	if start != "" {
+		parsed, err := time.Parse(parseLayout, start)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid start date format, expected dd-mm-yyyy")
+		}
+		startDate = parsed
+	}
+
+	if startDate.After(endDate) {
+		return time.Time{}, time.Time{}, errors.New("start date cannot be after end date")
+	}
+
+	return bucketStart(startDate, interval), endDate, nil
+}
+
+func bucketStart(t time.Time, interval string) time.Time {
+	switch interval {
+	case "week":
+		return utils.StartOfWeek(t)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return t
+	}
+}
+
+func timeseriesBuckets(startDate time.Time, endDate time.Time, interval string) []time.Time {
+	buckets := []time.Time{}
+	for bucket := startDate; !bucket.After(endDate); {
+		buckets = append(buckets, bucket)
+
+		switch interval {
+		case "week":
+			bucket = bucket.AddDate(0, 0, 7)
+		case "month":
+			bucket = bucket.AddDate(0, 1, 0)
+		default:
+			bucket = bucket.AddDate(0, 0, 1)
+		}
+
+		if len(buckets) > maxTimeseriesBuckets {
+			break
+		}
+	}
+
+	return buckets
+}
+
+func percentage(part float64, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(part/total*10000) / 100
+}
+
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/report/repository"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeReportRepo struct {
+	repository.ReportPostgreSQLItf
+
+	months  []dto.MRRMonth
+	cohorts []dto.CohortRetentionCount
+	from    time.Time
+	to      time.Time
+}
+
+func (r *fakeReportRepo) GetMRRMonths(from time.Time, to time.Time) ([]dto.MRRMonth, error) {
+	r.from, r.to = from, to
+	return r.months, nil
+}
+
+func (r *fakeReportRepo) GetCohortRetention(from time.Time, to time.Time, planId string, months int) ([]dto.CohortRetentionCount, error) {
+	r.from, r.to = from, to
+	return r.cohorts, nil
+}
+
+func newTestCtx(t *testing.T) *fiber.Ctx {
+	t.Helper()
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	t.Cleanup(func() { app.ReleaseCtx(ctx) })
+	return ctx
+}
+
+func month(year int, month time.Month) time.Time {
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetMRRReport(t *testing.T) {
+	repo := &fakeReportRepo{months: []dto.MRRMonth{
+		{
+			MonthStart:            month(2025, time.January),
+			StartingMRR:           1000,
+			NewMRR:                300,
+			ReactivationMRR:       50.5,
+			ExpansionMRR:          40,
+			ContractionMRR:        60,
+			ChurnedMRR:            200.25,
+			EndingMRR:             1130.25,
+			StartingSubscriptions: 8,
+			ChurnedSubscriptions:  2,
+		},
+		{
+			MonthStart:  month(2025, time.February),
+			StartingMRR: 0,
+			EndingMRR:   1200,
+		},
+	}}
+	u := &ReportUsecase{repo}
+
+	report, err := u.GetMRRReport(newTestCtx(t), dto.GetMRRReportQuery{StartMonth: "01-2025", EndMonth: "02-2025"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !repo.from.Equal(month(2025, time.January)) || !repo.to.Equal(month(2025, time.February)) {
+		t.Fatalf("queried %s to %s, want January to February 2025", repo.from, repo.to)
+	}
+	if report.StartMonth != "01-2025" || report.EndMonth != "02-2025" {
+		t.Fatalf("range = %s to %s", report.StartMonth, report.EndMonth)
+	}
+	if report.CurrentMRR != 1200 {
+		t.Fatalf("current MRR = %v, want the closing MRR of the last month", report.CurrentMRR)
+	}
+
+	january := report.Months[0]
+	if january.Month != "01-2025" {
+		t.Fatalf("month = %q, want %q", january.Month, "01-2025")
+	}
+	if january.NetNewMRR != 130.25 {
+		t.Fatalf("net new MRR = %v, want new + reactivation + expansion - contraction - churned", january.NetNewMRR)
+	}
+	if january.ChurnRate != 25 {
+		t.Fatalf("churn rate = %v, want 25", january.ChurnRate)
+	}
+	if january.RevenueChurnRate != 20.03 {
+		t.Fatalf("revenue churn rate = %v, want 20.03", january.RevenueChurnRate)
+	}
+
+	// A month without opening subscriptions has no churn instead of dividing by zero
+	if february := report.Months[1]; february.ChurnRate != 0 || february.RevenueChurnRate != 0 {
+		t.Fatalf("february churn = %v / %v, want 0", february.ChurnRate, february.RevenueChurnRate)
+	}
+}
+
+func TestGetMRRReportEmpty(t *testing.T) {
+	u := &ReportUsecase{&fakeReportRepo{}}
+
+	report, err := u.GetMRRReport(newTestCtx(t), dto.GetMRRReportQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.CurrentMRR != 0 {
+		t.Fatalf("current MRR = %v, want 0", report.CurrentMRR)
+	}
+
+	now := time.Now()
+	if want := month(now.Year(), now.Month()).Format(utils.MonthLayout); report.EndMonth != want {
+		t.Fatalf("end month = %s, want the current month %s", report.EndMonth, want)
+	}
+	if want := month(now.Year(), now.Month()).AddDate(0, -11, 0).Format(utils.MonthLayout); report.StartMonth != want {
+		t.Fatalf("start month = %s, want %s", report.StartMonth, want)
+	}
+}
+
+func TestGetCohortReport(t *testing.T) {
+	repo := &fakeReportRepo{cohorts: []dto.CohortRetentionCount{
+		{CohortMonth: month(2025, time.January), MonthOffset: 0, EligibleUsers: 4, ActiveUsers: 4},
+		{CohortMonth: month(2025, time.January), MonthOffset: 1, EligibleUsers: 4, ActiveUsers: 3},
+		{CohortMonth: month(2025, time.January), MonthOffset: 2, EligibleUsers: 3, ActiveUsers: 1},
+		{CohortMonth: month(2025, time.January), MonthOffset: cohortRetentionMonths + 1, EligibleUsers: 4, ActiveUsers: 1},
+		{CohortMonth: month(2025, time.February), MonthOffset: 0, EligibleUsers: 2, ActiveUsers: 2},
+		{CohortMonth: month(2025, time.February), MonthOffset: 1, EligibleUsers: 0, ActiveUsers: 0},
+	}}
+	u := &ReportUsecase{repo}
+
+	report, err := u.GetCohortReport(newTestCtx(t), dto.GetCohortReportQuery{StartMonth: "01-2025", EndMonth: "02-2025", PlanId: "diet"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.PlanId != "diet" || len(report.Cohorts) != 2 {
+		t.Fatalf("report = %+v, want two cohorts of plan diet", report)
+	}
+
+	january := report.Cohorts[0]
+	if january.Cohort != "01-2025" || january.Users != 4 {
+		t.Fatalf("cohort = %s with %d users, want 01-2025 with 4", january.Cohort, january.Users)
+	}
+	if len(january.Retention) != cohortRetentionMonths {
+		t.Fatalf("retention has %d months, want %d", len(january.Retention), cohortRetentionMonths)
+	}
+	if january.Retention[0] == nil || *january.Retention[0] != 75 {
+		t.Fatalf("month 1 retention = %v, want 75", january.Retention[0])
+	}
+	if january.Retention[1] == nil || *january.Retention[1] != 33.33 {
+		t.Fatalf("month 2 retention = %v, want 33.33", january.Retention[1])
+	}
+	for offset, retention := range january.Retention[2:] {
+		if retention != nil {
+			t.Fatalf("month %d retention = %v, want null for months without data", offset+3, *retention)
+		}
+	}
+
+	// Retention rows are attached to their own cohort
+	february := report.Cohorts[1]
+	if february.Users != 2 || february.Retention[0] == nil || *february.Retention[0] != 0 {
+		t.Fatalf("february = %+v, want 2 users and 0%% retention in month 1", february)
+	}
+}
+
+func TestGetCohortReportSkipsOrphanRetention(t *testing.T) {
+	repo := &fakeReportRepo{cohorts: []dto.CohortRetentionCount{
+		{CohortMonth: month(2025, time.January), MonthOffset: 1, EligibleUsers: 4, ActiveUsers: 3},
+	}}
+	u := &ReportUsecase{repo}
+
+	report, err := u.GetCohortReport(newTestCtx(t), dto.GetCohortReportQuery{StartMonth: "01-2025", EndMonth: "01-2025"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Cohorts) != 0 {
+		t.Fatalf("cohorts = %+v, want none without a cohort row", report.Cohorts)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/jevvonn/sea-catering-be/internal/app/subscription/usecase"
 	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
 	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
 	"github.com/jevvonn/sea-catering-be/internal/middleware"
 	"github.com/jevvonn/sea-catering-be/internal/models"
@@ -17,29 +18,83 @@ type SubscriptionHandler struct {
 
 func NewSubscriptionHandler(
 	router fiber.Router,
+	mw *middleware.Middleware,
 	subUsecase usecase.SubscriptionUsecaseItf,
 	validator validator.ValidationService,
 ) {
 	handler := SubscriptionHandler{subUsecase, validator}
 
-	router.Get("/subscriptions", middleware.Authenticated, handler.GetSubscriptions)
-	router.Get("/subscriptions/report", middleware.Authenticated, middleware.RequireRoles(constant.RoleAdmin), handler.GetSubscriptionsReport)
+	router.Get("/subscriptions", mw.Authenticated, handler.GetSubscriptions)
+	router.Get("/subscriptions/report", mw.Authenticated, mw.RequirePermission(constant.PermissionReportsRead), handler.GetSubscriptionsReport)
+	router.Get("/subscriptions/search", mw.Authenticated, mw.RequirePermission(constant.PermissionSupportSearch), handler.SearchSubscriptions)
 
-	router.Get("/subscriptions/:id", middleware.Authenticated, handler.GetSpecific)
-	router.Post("/subscriptions", middleware.Authenticated, handler.CreateSubscription)
-	router.Put("/subscriptions/:id", middleware.Authenticated, handler.UpdateSubscription)
+	router.Get("/subscriptions/:id", mw.Authenticated, handler.GetSpecific)
+	router.Get("/subscriptions/:id/allergen-conflicts", mw.Authenticated, handler.GetAllergenConflicts)
+	router.Get("/subscriptions/:id/meals", mw.Authenticated, handler.GetMealSelections)
+	router.Put("/subscriptions/:id/meals", mw.Authenticated, handler.UpdateMealSelections)
+	router.Post("/subscriptions", mw.Authenticated, handler.CreateSubscription)
+	router.Put("/subscriptions/:id", mw.Authenticated, handler.UpdateSubscription)
+	router.Get("/subscriptions/:id/retention-offer", mw.Authenticated, handler.GetRetentionOffer)
+	router.Post("/subscriptions/:id/cancel", mw.Authenticated, handler.CancelSubscription)
+	router.Delete("/subscriptions/:id/cancel", mw.Authenticated, handler.RevokeCancellation)
+	router.Post("/subscriptions/:id/reactivate", mw.Authenticated, handler.ReactivateSubscription)
 }
 
 // @Tags         Subscription
 // @Summary      Get All My Subscriptions
+// @Description  Admins get every subscription. With format=csv or xlsx every matching subscription is downloaded as a file, ignoring the page.
 // @Accept       json
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        status query string false "Status (ACTIVE, CANCELLED)"
+// @Param        plan_id query string false "Plan ID"
+// @Param        paused query string false "Paused right now (true, false)"
+// @Param        mealtype query string false "Meal type (Breakfast, Lunch, Dinner)"
+// @Param        delivery_day query string false "Delivery day (Monday ... Sunday)"
+// @Param        created_from query string false "Created on or after (dd-mm-yyyy)"
+// @Param        created_to query string false "Created on or before (dd-mm-yyyy)"
+// @Param        search query string false "Search by user email"
+// @Param        sort query string false "Sort (newest, oldest, price_high, price_low), defaults to newest"
+// @Param        limit query int false "Limit, defaults to 10"
+// @Param        page query int false "Page, defaults to 1"
+// @Param        format query string false "json (default), csv or xlsx"
 // @Router       /subscriptions [get]
 // @Security     BearerAuth
-// @Success      200  {object}  models.JSONResponseModel{data=[]dto.GetSubscriptionResponse}
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetSubscriptionsResponse}
 // @Failure      400  {object}  models.JSONResponseModel
 func (h *SubscriptionHandler) GetSubscriptions(ctx *fiber.Ctx) error {
-	subscriptions, err := h.subUsecase.GetSubscriptions(ctx)
+	var req dto.GetSubscriptionsQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.subUsecase.ExportSubscriptions(ctx, req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to export subscriptions",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "subscriptions", stream)
+	}
+
+	subscriptions, err := h.subUsecase.GetSubscriptions(ctx, req)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
@@ -57,6 +112,52 @@ func (h *SubscriptionHandler) GetSubscriptions(ctx *fiber.Ctx) error {
 	)
 }
 
+// @Tags         Subscription
+// @Summary      Search Subscriptions
+// @Description  Finds subscriptions by subscriber name, customer name or email, or a fragment of the phone number, best match first. Similar names match too, so small typos are tolerated. Matches are wrapped in <mark> tags in the highlights.
+// @Accept       json
+// @Produce      json
+// @Param        q query string true "Name, email or phone number fragment"
+// @Param        limit query int false "Limit, defaults to 20"
+// @Router       /subscriptions/search [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.SubscriptionSearchResult}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) SearchSubscriptions(ctx *fiber.Ctx) error {
+	var req dto.SearchSubscriptionsQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	results, err := h.subUsecase.SearchSubscriptions(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to search subscriptions",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Subscriptions found successfully",
+			Data:    results,
+		},
+	)
+}
+
 // @Tags         Subscription
 // @Summary      Get Spesicfic Subscription
 // @Accept       json
@@ -138,6 +239,7 @@ func (h *SubscriptionHandler) CreateSubscription(ctx *fiber.Ctx) error {
 
 // @Tags         Subscription
 // @Summary      Update Subscription
+// @Description  Updates the contact details, allergies and pause of a subscription, status ACTIVE ends a pause. Subscriptions are cancelled through POST /subscriptions/{subscriptionId}/cancel.
 // @Accept       json
 // @Produce      json
 // @Param        subscriptionId path string true "Subscription ID"
@@ -188,18 +290,205 @@ func (h *SubscriptionHandler) UpdateSubscription(ctx *fiber.Ctx) error {
 	)
 }
 
+// @Tags         Subscription
+// @Summary      Get Retention Offer
+// @Description  The offer to show before cancelling for a reason: a discount when it is too expensive, a pause for a break, moving or low usage. The type is empty when there is no offer, each subscription can accept one offer.
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId path string true "Subscription ID"
+// @Param        reason query string true "Cancellation reason (TOO_EXPENSIVE, NOT_USING_ENOUGH, FOOD_QUALITY, DIETARY_NEEDS, DELIVERY_ISSUES, MOVING, TEMPORARY_BREAK, OTHER)"
+// @Router       /subscriptions/{subscriptionId}/retention-offer [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.RetentionOfferResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) GetRetentionOffer(ctx *fiber.Ctx) error {
+	var req dto.GetRetentionOfferQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	offer, err := h.subUsecase.GetRetentionOffer(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get retention offer",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Retention offer retrieved successfully",
+			Data:    offer,
+		},
+	)
+}
+
+// @Tags         Subscription
+// @Summary      Cancel Subscription
+// @Description  Cancels right away, or with at_period_end at the next monthly anniversary of the subscription. With accept_offer the retention offer for the reason is applied instead and the subscription is kept.
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId path string true "Subscription ID"
+// @Param        request body dto.CancelSubscriptionRequest true "Request body"
+// @Router       /subscriptions/{subscriptionId}/cancel [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.CancelSubscriptionResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) CancelSubscription(ctx *fiber.Ctx) error {
+	var req dto.CancelSubscriptionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	result, err := h.subUsecase.CancelSubscription(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to cancel subscription",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	message := "Subscription cancelled successfully"
+	switch result.Outcome {
+	case constant.CancellationOutcomeScheduled:
+		message = "Subscription will be cancelled at the end of the billing period"
+	case constant.CancellationOutcomeRetained:
+		message = "Retention offer applied successfully"
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: message,
+			Data:    result,
+		},
+	)
+}
+
+// @Tags         Subscription
+// @Summary      Revoke Scheduled Cancellation
+// @Description  Keeps a subscription that was cancelled at the end of its billing period, before that date is reached.
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId path string true "Subscription ID"
+// @Router       /subscriptions/{subscriptionId}/cancel [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) RevokeCancellation(ctx *fiber.Ctx) error {
+	if err := h.subUsecase.RevokeCancellation(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to revoke cancellation",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Cancellation revoked successfully",
+		},
+	)
+}
+
+// @Tags         Subscription
+// @Summary      Reactivate Subscription
+// @Description  Restores a cancelled subscription with its options at the current price of the plan. The plan must be available and the customer must not have another active subscription for it.
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId path string true "Subscription ID"
+// @Router       /subscriptions/{subscriptionId}/reactivate [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) ReactivateSubscription(ctx *fiber.Ctx) error {
+	if err := h.subUsecase.ReactivateSubscription(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to reactivate subscription",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Subscription reactivated successfully",
+		},
+	)
+}
+
 // @Tags         Subscription
 // @Summary      Get Report Subscription
 // @Accept       json
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param        start_date query string false "e.g 29-06-2025"
 // @Param        end_date query string false "e.g 30-06-2025"
+// @Param        format query string false "json (default), csv or xlsx"
 // @Router       /subscriptions/report [get]
 // @Security     BearerAuth
 // @Success      200  {object}  models.JSONResponseModel{data=dto.GetSubscriptionReportResponse}
 // @Failure      400  {object}  models.JSONResponseModel
 func (h *SubscriptionHandler) GetSubscriptionsReport(ctx *fiber.Ctx) error {
-	report, err := h.subUsecase.GetSubscriptionsReport(ctx)
+	var req dto.GetSubscriptionReportQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if export.IsFile(req.Format) {
+		stream, err := h.subUsecase.ExportSubscriptionsReport(ctx, req)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to retrieve subscription report",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		return export.Send(ctx, req.Format, "subscription-report", stream)
+	}
+
+	report, err := h.subUsecase.GetSubscriptionsReport(ctx, req)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
@@ -216,3 +505,127 @@ func (h *SubscriptionHandler) GetSubscriptionsReport(ctx *fiber.Ctx) error {
 		},
 	)
 }
+
+// @Tags         Subscription
+// @Summary      Get Allergen Conflicts of a Subscription
+// @Description  Lists the dishes scheduled for the subscription in a week that contain one of its declared allergens. The week parameter accepts any date of the week, defaults to the current week.
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId path string true "Subscription ID"
+// @Param        week query string false "Any date of the week (dd-mm-yyyy)"
+// @Router       /subscriptions/{subscriptionId}/allergen-conflicts [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.AllergenConflict}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) GetAllergenConflicts(ctx *fiber.Ctx) error {
+	var req dto.GetWeeklyMenuQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	conflicts, err := h.subUsecase.GetAllergenConflicts(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve allergen conflicts",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Allergen conflicts retrieved successfully",
+			Data:    conflicts,
+		},
+	)
+}
+
+// @Tags         Subscription
+// @Summary      Get Meal Selections of a Subscription
+// @Description  Lists every meal delivered in a week with the dishes on the menu and the dish that will be served. The week parameter accepts any date of the week, defaults to the current week.
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId path string true "Subscription ID"
+// @Param        week query string false "Any date of the week (dd-mm-yyyy)"
+// @Router       /subscriptions/{subscriptionId}/meals [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=[]dto.MealSelectionResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) GetMealSelections(ctx *fiber.Ctx) error {
+	var req dto.GetMealSelectionsQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	meals, err := h.subUsecase.GetMealSelections(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve meal selections",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Meal selections retrieved successfully",
+			Data:    meals,
+		},
+	)
+}
+
+// @Tags         Subscription
+// @Summary      Pick Dishes for Upcoming Deliveries
+// @Description  Dishes can be picked until 48 hours before the delivery date. Meals without a pick get the default dish of the menu.
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId path string true "Subscription ID"
+// @Param        request body dto.UpdateMealSelectionsRequest true "Request body"
+// @Router       /subscriptions/{subscriptionId}/meals [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *SubscriptionHandler) UpdateMealSelections(ctx *fiber.Ctx) error {
+	var req dto.UpdateMealSelectionsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.subUsecase.UpdateMealSelections(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update meal selections",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Meal selections updated successfully",
+		},
+	)
+}
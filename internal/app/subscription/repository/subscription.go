@@ -1,19 +1,42 @@
 package repository
 
 import (
+	"errors"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SubscriptionPostgreSQLItf interface {
+	WithTx(tx *gorm.DB) SubscriptionPostgreSQLItf
+	Transaction(fn func(tx *gorm.DB) error) error
+
 	GetSubscriptions(cond entity.Subscription) ([]entity.Subscription, error)
 	GetSpecific(subscription entity.Subscription) (entity.Subscription, error)
+	ListSubscriptions(cond entity.Subscription, filter dto.SubscriptionFilter) ([]entity.Subscription, int64, error)
+	SearchSubscriptions(search string, digits string, limit int) ([]dto.SubscriptionSearchResult, error)
+	StreamSubscriptions(cond entity.Subscription, filter dto.SubscriptionFilter, fn func(batch []entity.Subscription) error) error
 	CreateSubscription(subscription entity.Subscription) error
-	UpdateSubscription(subscription entity.Subscription) error
-	GetActiveSubscriptions(startDate *time.Time, endDate *time.Time) ([]entity.Subscription, error)
+	CreateSubscriptions(subscriptions []entity.Subscription) error
+	UpdateSubscription(subscription entity.Subscription, allergyNote *string) error
+	CancelSubscription(cancellation entity.SubscriptionCancellation) error
+	RetainSubscription(subscription entity.Subscription, cancellation entity.SubscriptionCancellation) error
+	RevokeScheduledCancellation(subscriptionId uuid.UUID) error
+	CancelDueSubscriptions(now time.Time) (int64, error)
+	EndDueDiscounts(now time.Time) (int64, error)
+	ReactivateSubscription(subscriptionId uuid.UUID, totalPrice float64) error
+	GetActiveSubscriptionTotals(startDate *time.Time, endDate *time.Time) (int64, float64, error)
+	AnonymizeUserSubscriptions(userId uuid.UUID, name string) error
+	ReplaceSubscriptionAllergens(subscriptionId uuid.UUID, allergens []entity.Allergen) error
+	GetMealSelections(subscriptionId uuid.UUID, from time.Time, to time.Time) ([]entity.MealSelection, error)
+	SaveMealSelections(selections []entity.MealSelection) error
 }
 
 type SubscriptionPostgreSQL struct {
@@ -24,40 +47,116 @@ func NewSubscriptionPostgreSQL(db *gorm.DB) SubscriptionPostgreSQLItf {
 	return &SubscriptionPostgreSQL{db}
 }
 
+// WithTx runs the repository on a transaction of another repository
+func (r *SubscriptionPostgreSQL) WithTx(tx *gorm.DB) SubscriptionPostgreSQLItf {
+	return &SubscriptionPostgreSQL{tx}
+}
+
 func (r *SubscriptionPostgreSQL) GetSubscriptions(cond entity.Subscription) ([]entity.Subscription, error) {
 	var subscriptions []entity.Subscription
-	if err := r.db.Preload("Plans").Preload("User").Where(cond).Find(&subscriptions).Error; err != nil {
+	if err := r.db.Preload("Plans").Preload("User").Preload("Allergens").Where(cond).Find(&subscriptions).Error; err != nil {
 		return nil, err
 	}
 
 	return subscriptions, nil
 }
 
-func (r *SubscriptionPostgreSQL) GetActiveSubscriptions(startDate *time.Time, endDate *time.Time) ([]entity.Subscription, error) {
+func (r *SubscriptionPostgreSQL) ListSubscriptions(cond entity.Subscription, filter dto.SubscriptionFilter) ([]entity.Subscription, int64, error) {
 	var subscriptions []entity.Subscription
-	query := r.db.Model(&entity.Subscription{}).
-		Preload("Plans").
-		Preload("User")
+	var total int64
 
-	query = query.Where(entity.Subscription{
-		Status: "ACTIVE",
-	})
+	query := filterSubscriptions(r.db.Model(&entity.Subscription{}).Where(cond), filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := sortSubscriptions(query, filter.Sort).
+		Preload("Plans").Preload("User").Preload("Allergens").
+		Limit(filter.Limit).
+		Offset((filter.Page - 1) * filter.Limit).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return subscriptions, total, nil
+}
+
+// SearchSubscriptions finds subscriptions whose name, phone digits or customer name or email contain
+// the search, or whose names are similar to it, best match first. Every condition is backed by a
+// trigram index.
+func (r *SubscriptionPostgreSQL) SearchSubscriptions(search string, digits string, limit int) ([]dto.SubscriptionSearchResult, error) {
+	var results []dto.SubscriptionSearchResult
+	err := r.db.Raw(
+		`SELECT s.id, s.user_id, u.name AS user_name, u.email AS user_email, s.plan_id, COALESCE(p.name, '') AS plan_name,
+			s.name, s.phone_number, s.status, s.created_at,
+			GREATEST(
+				word_similarity(@search, s.name),
+				word_similarity(@search, u.name),
+				word_similarity(@search, u.email),
+				CASE WHEN @digits <> '' AND regexp_replace(s.phone_number, '[^0-9]', '', 'g') LIKE @digits_pattern THEN 1 ELSE 0 END
+			) AS score
+		FROM subscriptions s
+		JOIN users u ON u.id = s.user_id
+		LEFT JOIN plans p ON p.id = s.plan_id
+		WHERE s.name ILIKE @pattern OR u.name ILIKE @pattern OR u.email ILIKE @pattern
+			OR @search <% s.name OR @search <% u.name
+			OR (@digits <> '' AND regexp_replace(s.phone_number, '[^0-9]', '', 'g') LIKE @digits_pattern)
+		ORDER BY score DESC, s.created_at DESC
+		LIMIT @limit`,
+		map[string]any{
+			"search":         search,
+			"pattern":        "%" + escapeLike(search) + "%",
+			"digits":         digits,
+			"digits_pattern": "%" + digits + "%",
+			"limit":          limit,
+		},
+	).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetActiveSubscriptionTotals counts the active subscriptions and sums their monthly price,
+// optionally only those created within the date range
+func (r *SubscriptionPostgreSQL) GetActiveSubscriptionTotals(startDate *time.Time, endDate *time.Time) (int64, float64, error) {
+	var totals struct {
+		Count   int64
+		Revenue float64
+	}
+
+	query := r.db.Model(&entity.Subscription{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(total_price), 0) AS revenue").
+		Where("status = ?", constant.SubscriptionStatusActive)
 
 	if startDate != nil && endDate != nil {
 		query = query.Where("created_at BETWEEN ? AND ?", startDate, endDate)
 	}
 
-	if err := query.Find(&subscriptions).Error; err != nil {
-		return nil, err
+	if err := query.Scan(&totals).Error; err != nil {
+		return 0, 0, err
 	}
 
-	return subscriptions, nil
+	return totals.Count, totals.Revenue, nil
+}
+
+// StreamSubscriptions loads the subscriptions in batches so exports never hold all of them in memory
+func (r *SubscriptionPostgreSQL) StreamSubscriptions(cond entity.Subscription, filter dto.SubscriptionFilter, fn func(batch []entity.Subscription) error) error {
+	var batch []entity.Subscription
+
+	// FindInBatches pages by primary key, so the export keeps that order whatever the sort
+	return filterSubscriptions(r.db.Preload("Plans").Preload("User").Preload("Allergens").Where(cond), filter).
+		FindInBatches(&batch, constant.ExportBatchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
 }
 
 func (r *SubscriptionPostgreSQL) GetSpecific(subscription entity.Subscription) (entity.Subscription, error) {
 	var result entity.Subscription
 
-	if err := r.db.Preload("Plans").Preload("User").First(&result, &subscription).Error; err != nil {
+	if err := r.db.Preload("Plans").Preload("User").Preload("Allergens").First(&result, &subscription).Error; err != nil {
 		return entity.Subscription{}, err
 	}
 
@@ -65,14 +164,34 @@ func (r *SubscriptionPostgreSQL) GetSpecific(subscription entity.Subscription) (
 }
 
 func (r *SubscriptionPostgreSQL) CreateSubscription(subscription entity.Subscription) error {
-	if err := r.db.Create(&subscription).Error; err != nil {
-		return err
-	}
+	return r.CreateSubscriptions([]entity.Subscription{subscription})
+}
 
-	return nil
+// CreateSubscriptions creates all the subscriptions or none of them
+func (r *SubscriptionPostgreSQL) CreateSubscriptions(subscriptions []entity.Subscription) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, subscription := range subscriptions {
+			if err := tx.Omit("Allergens.*").Create(&subscription).Error; err != nil {
+				return err
+			}
+
+			err := createSubscriptionEvent(tx, subscription.ID, constant.SubscriptionEventCreated, subscription.TotalPrice, subscription.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Transaction runs fn in a transaction the repository joins with WithTx
+func (r *SubscriptionPostgreSQL) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
 }
 
-func (r *SubscriptionPostgreSQL) UpdateSubscription(subscription entity.Subscription) error {
+// UpdateSubscription updates the given fields, the allergy note only when allergyNote is set
+func (r *SubscriptionPostgreSQL) UpdateSubscription(subscription entity.Subscription, allergyNote *string) error {
 	if subscription.ID == uuid.Nil {
 		return gorm.ErrRecordNotFound
 	}
@@ -89,12 +208,456 @@ func (r *SubscriptionPostgreSQL) UpdateSubscription(subscription entity.Subscrip
 	if subscription.Status != "" {
 		data["status"] = subscription.Status
 	}
+	if allergyNote != nil {
+		data["allergy_note"] = *allergyNote
+	}
 	data["pause_start_date"] = subscription.PauseStartDate
 	data["pause_end_date"] = subscription.PauseEndDate
 
-	if err := r.db.Model(entity.Subscription{}).Where("id = ?", subscription.ID).Updates(&data).Error; err != nil {
-		return err
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Locked so an update can not reactivate a subscription cancelled at the same time
+		var current entity.Subscription
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id, status").
+			Where("id = ?", subscription.ID).
+			First(&current).Error
+		if err != nil {
+			return err
+		}
+
+		if current.Status == constant.SubscriptionStatusCancelled {
+			return errors.New("subscription is already cancelled")
+		}
+
+		return tx.Model(entity.Subscription{}).Where("id = ?", subscription.ID).Updates(&data).Error
+	})
+}
+
+// CancelSubscription records the cancellation request. Without an effective date in the future the
+// subscription ends right away, otherwise it is scheduled to end then. A new request replaces a
+// scheduled one.
+func (r *SubscriptionPostgreSQL) CancelSubscription(cancellation entity.SubscriptionCancellation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current entity.Subscription
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id, status, total_price, discount_percent, discount_ends_at").
+			Where("id = ?", cancellation.SubscriptionID).
+			First(&current).Error
+		if err != nil {
+			return err
+		}
+
+		if current.Status == constant.SubscriptionStatusCancelled {
+			return errors.New("subscription is already cancelled")
+		}
+
+		err = tx.Model(&entity.SubscriptionCancellation{}).
+			Where("subscription_id = ? AND outcome = ?", current.ID, constant.CancellationOutcomeScheduled).
+			Update("outcome", constant.CancellationOutcomeRevoked).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		data := map[string]any{}
+		if cancellation.EffectiveAt != nil && cancellation.EffectiveAt.After(now) {
+			cancellation.Outcome = constant.CancellationOutcomeScheduled
+			data["cancel_at"] = cancellation.EffectiveAt
+		} else {
+			cancellation.Outcome = constant.CancellationOutcomeCancelled
+			cancellation.EffectiveAt = &now
+			data["status"] = constant.SubscriptionStatusCancelled
+			data["cancelled_at"] = now
+			data["cancel_at"] = nil
+
+			if err := createSubscriptionEvent(tx, current.ID, constant.SubscriptionEventCancelled, subscriptionMRR(current, now), now); err != nil {
+				return err
+			}
+		}
+
+		cancellation.ID = uuid.New()
+		cancellation.MRR = subscriptionMRR(current, now)
+		if err := tx.Create(&cancellation).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(entity.Subscription{}).Where("id = ?", current.ID).Updates(data).Error
+	})
+}
+
+// RetainSubscription applies the accepted retention offer and records the request it prevented.
+// A discount is recorded as a change of the MRR.
+func (r *SubscriptionPostgreSQL) RetainSubscription(subscription entity.Subscription, cancellation entity.SubscriptionCancellation) error {
+	data := map[string]any{
+		"retention_offer_at": subscription.RetentionOfferAt,
+	}
+
+	if subscription.DiscountPercent != 0 {
+		data["discount_percent"] = subscription.DiscountPercent
+		data["discount_ends_at"] = subscription.DiscountEndsAt
+	}
+
+	if subscription.PauseStartDate != nil {
+		data["pause_start_date"] = subscription.PauseStartDate
+		data["pause_end_date"] = subscription.PauseEndDate
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(entity.Subscription{}).Where("id = ?", subscription.ID).Updates(data).Error; err != nil {
+			return err
+		}
+
+		if subscription.DiscountPercent != 0 {
+			at := *subscription.RetentionOfferAt
+			err := createSubscriptionEvent(tx, subscription.ID, constant.SubscriptionEventMRRChanged, subscriptionMRR(subscription, at), at)
+			if err != nil {
+				return err
+			}
+		}
+
+		cancellation.ID = uuid.New()
+		cancellation.SubscriptionID = subscription.ID
+		cancellation.Outcome = constant.CancellationOutcomeRetained
+		cancellation.MRR = subscription.TotalPrice
+		return tx.Create(&cancellation).Error
+	})
+}
+
+// RevokeScheduledCancellation keeps a subscription whose cancellation has not taken effect yet
+func (r *SubscriptionPostgreSQL) RevokeScheduledCancellation(subscriptionId uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.SubscriptionCancellation{}).
+			Where("subscription_id = ? AND outcome = ?", subscriptionId, constant.CancellationOutcomeScheduled).
+			Updates(map[string]any{"outcome": constant.CancellationOutcomeRevoked, "effective_at": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.New("subscription has no scheduled cancellation")
+		}
+
+		return tx.Model(entity.Subscription{}).Where("id = ?", subscriptionId).Update("cancel_at", nil).Error
+	})
+}
+
+// CancelDueSubscriptions ends the subscriptions whose scheduled cancellation is due, as of their
+// scheduled date, and returns how many were cancelled
+func (r *SubscriptionPostgreSQL) CancelDueSubscriptions(now time.Time) (int64, error) {
+	var due []entity.Subscription
+	err := r.db.Select("id, total_price, cancel_at, discount_percent, discount_ends_at").
+		Where("status = ? AND cancel_at <= ?", constant.SubscriptionStatusActive, now).
+		Find(&due).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var cancelled int64
+	for _, subscription := range due {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			// Skip subscriptions cancelled or revoked since they were loaded
+			result := tx.Model(entity.Subscription{}).
+				Where("id = ? AND status = ? AND cancel_at = ?", subscription.ID, constant.SubscriptionStatusActive, subscription.CancelAt).
+				Updates(map[string]any{
+					"status":       constant.SubscriptionStatusCancelled,
+					"cancelled_at": subscription.CancelAt,
+					"cancel_at":    nil,
+				})
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			err := tx.Model(&entity.SubscriptionCancellation{}).
+				Where("subscription_id = ? AND outcome = ?", subscription.ID, constant.CancellationOutcomeScheduled).
+				Update("outcome", constant.CancellationOutcomeCancelled).Error
+			if err != nil {
+				return err
+			}
+
+			err = createSubscriptionEvent(tx, subscription.ID, constant.SubscriptionEventCancelled, subscriptionMRR(subscription, *subscription.CancelAt), *subscription.CancelAt)
+			if err != nil {
+				return err
+			}
+
+			cancelled++
+			return nil
+		})
+		if err != nil {
+			return cancelled, err
+		}
 	}
 
-	return nil
+	return cancelled, nil
+}
+
+// discountNotEnded matches subscriptions whose discount end has not been recorded yet
+const discountNotEnded = `NOT EXISTS (
+	SELECT 1 FROM subscription_events e
+	WHERE e.subscription_id = subscriptions.id AND e.type = ? AND e.occurred_at = subscriptions.discount_ends_at
+)`
+
+// EndDueDiscounts records the MRR of active subscriptions going back to the full price when their
+// retention discount ends, as of the end of the discount, and returns how many were recorded
+func (r *SubscriptionPostgreSQL) EndDueDiscounts(now time.Time) (int64, error) {
+	var due []entity.Subscription
+	err := r.db.Select("id, total_price, discount_ends_at").
+		Where("status = ? AND discount_percent > 0 AND discount_ends_at <= ?", constant.SubscriptionStatusActive, now).
+		Where(discountNotEnded, constant.SubscriptionEventMRRChanged).
+		Find(&due).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var ended int64
+	for _, subscription := range due {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			// Skip subscriptions cancelled, reactivated or recorded elsewhere since they were loaded
+			var current []entity.Subscription
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id").
+				Where("id = ? AND status = ? AND discount_ends_at = ?", subscription.ID, constant.SubscriptionStatusActive, subscription.DiscountEndsAt).
+				Where(discountNotEnded, constant.SubscriptionEventMRRChanged).
+				Find(&current)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			err := createSubscriptionEvent(tx, subscription.ID, constant.SubscriptionEventMRRChanged, subscription.TotalPrice, *subscription.DiscountEndsAt)
+			if err != nil {
+				return err
+			}
+
+			ended++
+			return nil
+		})
+		if err != nil {
+			return ended, err
+		}
+	}
+
+	return ended, nil
+}
+
+// ReactivateSubscription restores a cancelled subscription at the given price, clearing the pause
+// and the discount it had when it was cancelled
+func (r *SubscriptionPostgreSQL) ReactivateSubscription(subscriptionId uuid.UUID, totalPrice float64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(entity.Subscription{}).
+			Where("id = ? AND status = ?", subscriptionId, constant.SubscriptionStatusCancelled).
+			Updates(map[string]any{
+				"status":           constant.SubscriptionStatusActive,
+				"total_price":      totalPrice,
+				"cancelled_at":     nil,
+				"cancel_at":        nil,
+				"pause_start_date": nil,
+				"pause_end_date":   nil,
+				"discount_percent": 0,
+				"discount_ends_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.New("subscription is not cancelled")
+		}
+
+		return createSubscriptionEvent(tx, subscriptionId, constant.SubscriptionEventReactivated, totalPrice, now)
+	})
+}
+
+// subscriptionMRR is the monthly value of a subscription at a moment, lowered by its retention
+// discount until the discount ends
+func subscriptionMRR(subscription entity.Subscription, at time.Time) float64 {
+	if subscription.DiscountPercent == 0 || subscription.DiscountEndsAt == nil || !subscription.DiscountEndsAt.After(at) {
+		return subscription.TotalPrice
+	}
+
+	return math.Round(subscription.TotalPrice*float64(100-subscription.DiscountPercent)) / 100
+}
+
+// createSubscriptionEvent appends a status change to the history revenue analytics are based on
+func createSubscriptionEvent(tx *gorm.DB, subscriptionId uuid.UUID, eventType string, mrr float64, occurredAt time.Time) error {
+	return tx.Create(&entity.SubscriptionEvent{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionId,
+		Type:           eventType,
+		MRR:            mrr,
+		OccurredAt:     occurredAt,
+	}).Error
+}
+
+// AnonymizeUserSubscriptions removes personal data from a user's subscriptions and cancels the active ones,
+// keeping plan, price and dates so revenue reports stay intact
+func (r *SubscriptionPostgreSQL) AnonymizeUserSubscriptions(userId uuid.UUID, name string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var active []entity.Subscription
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id, total_price, discount_percent, discount_ends_at").
+			Where("user_id = ? AND status = ?", userId, constant.SubscriptionStatusActive).
+			Find(&active).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, subscription := range active {
+			mrr := subscriptionMRR(subscription, now)
+			if err := createSubscriptionEvent(tx, subscription.ID, constant.SubscriptionEventCancelled, mrr, now); err != nil {
+				return err
+			}
+
+			// A scheduled request ends early, otherwise the churn report counts it without a reason
+			result := tx.Model(&entity.SubscriptionCancellation{}).
+				Where("subscription_id = ? AND outcome = ?", subscription.ID, constant.CancellationOutcomeScheduled).
+				Updates(map[string]any{"outcome": constant.CancellationOutcomeCancelled, "effective_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				err := tx.Create(&entity.SubscriptionCancellation{
+					ID:             uuid.New(),
+					SubscriptionID: subscription.ID,
+					Reason:         constant.CancellationReasonUnspecified,
+					Outcome:        constant.CancellationOutcomeCancelled,
+					MRR:            mrr,
+					EffectiveAt:    &now,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err = tx.Model(entity.Subscription{}).
+			Where("user_id = ? AND status = ?", userId, constant.SubscriptionStatusActive).
+			Updates(map[string]any{
+				"status":       constant.SubscriptionStatusCancelled,
+				"cancelled_at": now,
+				"cancel_at":    nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// The reasons stay for the churn report, the free text may identify the customer
+		err = tx.Model(&entity.SubscriptionCancellation{}).
+			Where("subscription_id IN (SELECT id FROM subscriptions WHERE user_id = ?)", userId).
+			Update("note", "").Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(
+			"DELETE FROM subscription_allergens WHERE subscription_id IN (SELECT id FROM subscriptions WHERE user_id = ?)",
+			userId,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(entity.Subscription{}).Where("user_id = ?", userId).Updates(map[string]any{
+			"name":         name,
+			"phone_number": "",
+			"allergy_note": "",
+		}).Error
+	})
+}
+
+func (r *SubscriptionPostgreSQL) ReplaceSubscriptionAllergens(subscriptionId uuid.UUID, allergens []entity.Allergen) error {
+	association := r.db.Model(&entity.Subscription{ID: subscriptionId}).Omit("Allergens.*").Association("Allergens")
+	if len(allergens) == 0 {
+		return association.Clear()
+	}
+
+	return association.Replace(allergens)
+}
+
+func (r *SubscriptionPostgreSQL) GetMealSelections(subscriptionId uuid.UUID, from time.Time, to time.Time) ([]entity.MealSelection, error) {
+	var selections []entity.MealSelection
+	err := r.db.
+		Where("subscription_id = ? AND delivery_date BETWEEN ? AND ?", subscriptionId, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Find(&selections).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return selections, nil
+}
+
+// SaveMealSelections creates the selections or replaces the picked dish of existing ones
+func (r *SubscriptionPostgreSQL) SaveMealSelections(selections []entity.MealSelection) error {
+	if len(selections) == 0 {
+		return nil
+	}
+
+	return r.db.Omit("Subscription", "Dish").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "delivery_date"}, {Name: "mealtype"}},
+		DoUpdates: clause.AssignmentColumns([]string{"dish_id", "updated_at"}),
+	}).Create(&selections).Error
+}
+
+// filterSubscriptions applies the listing filters. Meal types and delivery days are stored
+// comma separated, a subscription matches when any of its values does.
+func filterSubscriptions(query *gorm.DB, filter dto.SubscriptionFilter) *gorm.DB {
+	if filter.Status != "" {
+		query = query.Where("subscriptions.status = ?", filter.Status)
+	}
+
+	if filter.PlanId != "" {
+		query = query.Where("subscriptions.plan_id = ?", filter.PlanId)
+	}
+
+	if filter.Paused != nil {
+		// Subscriptions without a pause window compare as NULL, which counts as not paused
+		now := time.Now()
+		paused := "COALESCE(subscriptions.pause_start_date <= ? AND subscriptions.pause_end_date > ?, FALSE)"
+		if !*filter.Paused {
+			paused = "NOT " + paused
+		}
+		query = query.Where(paused, now, now)
+	}
+
+	if filter.Mealtype != "" {
+		query = query.Where("? = ANY(string_to_array(subscriptions.mealtypes, ','))", filter.Mealtype)
+	}
+
+	if filter.DeliveryDay != "" {
+		query = query.Where("? = ANY(string_to_array(subscriptions.delivery_days, ','))", filter.DeliveryDay)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("subscriptions.created_at >= ?", filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("subscriptions.created_at < ?", filter.CreatedTo.AddDate(0, 0, 1))
+	}
+
+	if filter.Search != "" {
+		query = query.Where("subscriptions.user_id IN (SELECT id FROM users WHERE email ILIKE ?)", "%"+filter.Search+"%")
+	}
+
+	return query
+}
+
+// sortSubscriptions orders the listing, the id breaks ties so pages never overlap
+func sortSubscriptions(query *gorm.DB, sort string) *gorm.DB {
+	switch sort {
+	case "oldest":
+		return query.Order("subscriptions.created_at ASC, subscriptions.id")
+	case "price_high":
+		return query.Order("subscriptions.total_price DESC, subscriptions.created_at DESC, subscriptions.id")
+	case "price_low":
+		return query.Order("subscriptions.total_price ASC, subscriptions.created_at DESC, subscriptions.id")
+	default:
+		return query.Order("subscriptions.created_at DESC, subscriptions.id")
+	}
+}
+
+// escapeLike makes LIKE wildcards in user input match literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
 }
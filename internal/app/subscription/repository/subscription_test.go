@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// exportRow is a subscription stored in the fake database
+type exportRow struct {
+	id        string
+	createdAt time.Time
+}
+
+// exportDriver answers the queries of StreamSubscriptions the way PostgreSQL would: the
+// subscriptions are filtered on the id, sorted by the ORDER BY and cut at the LIMIT.
+// Preloads of the associations find nothing.
+type exportDriver struct {
+	rows []exportRow
+}
+
+var (
+	exportIdAfter = regexp.MustCompile(`"subscriptions"\."id" > \$(\d+)`)
+	exportLimit   = regexp.MustCompile(`LIMIT (?:\$(\d+)|(\d+))`)
+	exportOrder   = regexp.MustCompile(`ORDER BY (.+?)(?: LIMIT|$)`)
+)
+
+func (d *exportDriver) Open(name string) (driver.Conn, error) {
+	return &exportConn{d}, nil
+}
+
+type exportConn struct {
+	driver *exportDriver
+}
+
+func (c *exportConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *exportConn) Close() error {
+	return nil
+}
+
+func (c *exportConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *exportConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, `FROM "subscriptions"`) {
+		return &exportRows{}, nil
+	}
+
+	arg := func(match []string) driver.Value {
+		index, _ := strconv.Atoi(match[1])
+		return args[index-1].Value
+	}
+
+	rows := []exportRow{}
+	for _, row := range c.driver.rows {
+		if match := exportIdAfter.FindStringSubmatch(query); match != nil && row.id <= arg(match).(string) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if match := exportOrder.FindStringSubmatch(query); match != nil {
+		byCreatedAt := strings.Contains(strings.Split(match[1], ",")[0], "created_at")
+		sort.SliceStable(rows, func(i, j int) bool {
+			if byCreatedAt {
+				return rows[i].createdAt.Before(rows[j].createdAt)
+			}
+			return rows[i].id < rows[j].id
+		})
+	}
+
+	if match := exportLimit.FindStringSubmatch(query); match != nil {
+		limit, _ := strconv.Atoi(match[2])
+		if match[1] != "" {
+			limit = int(arg(match).(int64))
+		}
+		rows = rows[:min(limit, len(rows))]
+	}
+
+	return &exportRows{rows: rows}, nil
+}
+
+type exportRows struct {
+	rows []exportRow
+}
+
+func (r *exportRows) Columns() []string {
+	return []string{"id", "created_at"}
+}
+
+func (r *exportRows) Close() error {
+	return nil
+}
+
+func (r *exportRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	dest[0], dest[1] = r.rows[0].id, r.rows[0].createdAt
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestStreamSubscriptionsPagesEveryRowOnce(t *testing.T) {
+	// Newer subscriptions get lower ids, so creation order and id order disagree
+	total := constant.ExportBatchSize*2 + 3
+	ids := make([]string, total)
+	for i := range ids {
+		ids[i] = uuid.NewString()
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+
+	exportDB := &exportDriver{}
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range ids {
+		exportDB.rows = append(exportDB.rows, exportRow{id: id, createdAt: start.Add(time.Duration(i) * time.Minute)})
+	}
+
+	driverName := "export-" + t.Name()
+	sql.Register(driverName, exportDB)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: driverName}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[uuid.UUID]int{}
+	batches := 0
+	err = NewSubscriptionPostgreSQL(db).StreamSubscriptions(entity.Subscription{}, dto.SubscriptionFilter{}, func(batch []entity.Subscription) error {
+		batches++
+		if len(batch) > constant.ExportBatchSize {
+			t.Fatalf("batch of %d rows, want at most %d", len(batch), constant.ExportBatchSize)
+		}
+
+		for _, subscription := range batch {
+			seen[subscription.ID]++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if batches != 3 {
+		t.Fatalf("streamed %d batches, want 3", batches)
+	}
+	if len(seen) != total {
+		t.Fatalf("streamed %d distinct subscriptions, want %d", len(seen), total)
+	}
+	for id, count := range seen {
+		if count != 1 {
+			t.Fatalf("subscription %s streamed %d times", id, count)
+		}
+	}
+}
+
+func TestSubscriptionMRR(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := now.AddDate(0, 3, 0)
+
+	tests := []struct {
+		name         string
+		subscription entity.Subscription
+		at           time.Time
+		want         float64
+	}{
+		{"no discount", entity.Subscription{TotalPrice: 129.99}, now, 129.99},
+		{"discount running", entity.Subscription{TotalPrice: 129.99, DiscountPercent: 20, DiscountEndsAt: &endsAt}, now, 103.99},
+		{"discount ended", entity.Subscription{TotalPrice: 129.99, DiscountPercent: 20, DiscountEndsAt: &endsAt}, endsAt, 129.99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subscriptionMRR(tt.subscription, tt.at); got != tt.want {
+				t.Fatalf("MRR = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,60 +1,111 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"html"
+	"log"
+	"math"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	menuRepo "github.com/jevvonn/sea-catering-be/internal/app/menu/repository"
+	menuUsecase "github.com/jevvonn/sea-catering-be/internal/app/menu/usecase"
+	orgRepo "github.com/jevvonn/sea-catering-be/internal/app/organization/repository"
+	permissionUsecase "github.com/jevvonn/sea-catering-be/internal/app/permission/usecase"
 	plansRepo "github.com/jevvonn/sea-catering-be/internal/app/plans/repository"
 	subRepo "github.com/jevvonn/sea-catering-be/internal/app/subscription/repository"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
 	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"github.com/jevvonn/sea-catering-be/internal/infra/export"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
 	"gorm.io/gorm"
 )
 
 type SubscriptionUsecaseItf interface {
-	GetSubscriptions(ctx *fiber.Ctx) ([]dto.GetSubscriptionResponse, error)
+	GetSubscriptions(ctx *fiber.Ctx, query dto.GetSubscriptionsQuery) (dto.GetSubscriptionsResponse, error)
+	GetOrganizationSubscriptions(organizationId uuid.UUID, query dto.GetSubscriptionsQuery) (dto.GetSubscriptionsResponse, error)
+	CreateOrganizationSubscriptions(organizationId uuid.UUID, req dto.CreateOrganizationSubscriptionsRequest) (dto.CreateOrganizationSubscriptionsResponse, error)
+	SearchSubscriptions(query dto.SearchSubscriptionsQuery) ([]dto.SubscriptionSearchResult, error)
 	GetSpecific(ctx *fiber.Ctx) (dto.GetSubscriptionResponse, error)
 	CreateSubscription(ctx *fiber.Ctx, req dto.CreateSubscriptionRequest) error
 	UpdateSubscription(ctx *fiber.Ctx, req dto.UpdateSubscriptionRequest) error
-	GetSubscriptionsReport(ctx *fiber.Ctx) (dto.GetSubscriptionReportResponse, error)
+	GetRetentionOffer(ctx *fiber.Ctx, query dto.GetRetentionOfferQuery) (dto.RetentionOfferResponse, error)
+	CancelSubscription(ctx *fiber.Ctx, req dto.CancelSubscriptionRequest) (dto.CancelSubscriptionResponse, error)
+	RevokeCancellation(ctx *fiber.Ctx) error
+	ReactivateSubscription(ctx *fiber.Ctx) error
+	RunScheduledCancellations(ctx context.Context, interval time.Duration)
+	ExportSubscriptions(ctx *fiber.Ctx, query dto.GetSubscriptionsQuery) (export.StreamFunc, error)
+	GetSubscriptionsReport(ctx *fiber.Ctx, query dto.GetSubscriptionReportQuery) (dto.GetSubscriptionReportResponse, error)
+	ExportSubscriptionsReport(ctx *fiber.Ctx, query dto.GetSubscriptionReportQuery) (export.StreamFunc, error)
+	GetAllergenConflicts(ctx *fiber.Ctx, query dto.GetWeeklyMenuQuery) ([]dto.AllergenConflict, error)
+	GetMealSelections(ctx *fiber.Ctx, query dto.GetMealSelectionsQuery) ([]dto.MealSelectionResponse, error)
+	UpdateMealSelections(ctx *fiber.Ctx, req dto.UpdateMealSelectionsRequest) error
 }
 
 type SubscriptionUsecase struct {
-	subRepo   subRepo.SubscriptionPostgreSQLItf
-	plansRepo plansRepo.PlansPostgreSQLItf
+	subRepo           subRepo.SubscriptionPostgreSQLItf
+	plansRepo         plansRepo.PlansPostgreSQLItf
+	userRepo          userRepo.UserPostgreSQLItf
+	menuRepo          menuRepo.MenuPostgreSQLItf
+	orgRepo           orgRepo.OrganizationPostgreSQLItf
+	permissionUsecase permissionUsecase.PermissionUsecaseItf
 }
 
 func NewSubscriptionUsecase(
 	subRepo subRepo.SubscriptionPostgreSQLItf,
 	plansRepo plansRepo.PlansPostgreSQLItf,
+	userRepo userRepo.UserPostgreSQLItf,
+	menuRepo menuRepo.MenuPostgreSQLItf,
+	orgRepo orgRepo.OrganizationPostgreSQLItf,
+	permissionUsecase permissionUsecase.PermissionUsecaseItf,
 ) SubscriptionUsecaseItf {
-	return &SubscriptionUsecase{subRepo, plansRepo}
+	return &SubscriptionUsecase{subRepo, plansRepo, userRepo, menuRepo, orgRepo, permissionUsecase}
 }
 
-func (u *SubscriptionUsecase) GetSubscriptions(ctx *fiber.Ctx) ([]dto.GetSubscriptionResponse, error) {
-	userId := ctx.Locals("userId").(string)
-	role := ctx.Locals("role").(string)
+func (u *SubscriptionUsecase) GetSubscriptions(ctx *fiber.Ctx, query dto.GetSubscriptionsQuery) (dto.GetSubscriptionsResponse, error) {
+	condition, err := u.readableSubscriptions(ctx)
+	if err != nil {
+		return dto.GetSubscriptionsResponse{}, err
+	}
 
-	condition := entity.Subscription{}
-	if role != constant.RoleAdmin {
-		condition.UserID = uuid.MustParse(userId)
+	return u.listSubscriptions(condition, query)
+}
+
+// GetOrganizationSubscriptions lists the subscriptions billed to an organization, access to
+// the organization is checked by the caller
+func (u *SubscriptionUsecase) GetOrganizationSubscriptions(organizationId uuid.UUID, query dto.GetSubscriptionsQuery) (dto.GetSubscriptionsResponse, error) {
+	return u.listSubscriptions(entity.Subscription{OrganizationID: &organizationId}, query)
+}
+
+func (u *SubscriptionUsecase) listSubscriptions(condition entity.Subscription, query dto.GetSubscriptionsQuery) (dto.GetSubscriptionsResponse, error) {
+	if query.Limit <= 0 {
+		query.Limit = 10
 	}
 
-	subscriptions, err := u.subRepo.GetSubscriptions(condition)
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+
+	filter, err := toSubscriptionFilter(query)
 	if err != nil {
-		return nil, err
+		return dto.GetSubscriptionsResponse{}, err
 	}
 
-	var response []dto.GetSubscriptionResponse
+	subscriptions, total, err := u.subRepo.ListSubscriptions(condition, filter)
+	if err != nil {
+		return dto.GetSubscriptionsResponse{}, err
+	}
+
+	response := []dto.GetSubscriptionResponse{}
 	for _, sub := range subscriptions {
-		allergies := []string{}
-		if sub.Allergies != "" {
-			allergies = strings.Split(sub.Allergies, ",")
-		}
 		isPaused := false
 		if sub.PauseStartDate != nil && sub.PauseEndDate != nil {
 			if sub.PauseStartDate.Before(time.Now()) && sub.PauseEndDate.After(time.Now()) {
@@ -63,33 +114,246 @@ func (u *SubscriptionUsecase) GetSubscriptions(ctx *fiber.Ctx) ([]dto.GetSubscri
 		}
 
 		response = append(response, dto.GetSubscriptionResponse{
-			ID:     sub.ID,
-			UserID: sub.UserID,
+			ID:             sub.ID,
+			UserID:         sub.UserID,
+			OrganizationID: sub.OrganizationID,
 			User: dto.GetUserResponse{
 				ID:    sub.User.ID,
 				Name:  sub.User.Name,
 				Email: sub.User.Email,
 			},
-			PlanId:         sub.PlanId,
-			Plans:          sub.Plans,
-			Name:           sub.Name,
-			PhoneNumber:    sub.PhoneNumber,
-			Mealtypes:      strings.Split(sub.Mealtypes, ","),
-			DeliveryDays:   strings.Split(sub.DeliveryDays, ","),
-			Allergies:      allergies,
-			TotalPrice:     sub.TotalPrice,
-			Status:         sub.Status,
-			PauseStartDate: sub.PauseStartDate,
-			PauseEndDate:   sub.PauseEndDate,
-			CreatedAt:      sub.CreatedAt,
-			UpdatedAt:      sub.UpdatedAt,
-			IsPaused:       isPaused,
+			PlanId:          sub.PlanId,
+			Plans:           sub.Plans,
+			Name:            sub.Name,
+			PhoneNumber:     sub.PhoneNumber,
+			Mealtypes:       strings.Split(sub.Mealtypes, ","),
+			DeliveryDays:    strings.Split(sub.DeliveryDays, ","),
+			Allergens:       menuUsecase.ToAllergenResponses(sub.Allergens),
+			AllergyNote:     sub.AllergyNote,
+			TotalPrice:      sub.TotalPrice,
+			Status:          sub.Status,
+			PauseStartDate:  sub.PauseStartDate,
+			PauseEndDate:    sub.PauseEndDate,
+			CancelAt:        sub.CancelAt,
+			DiscountPercent: sub.DiscountPercent,
+			DiscountEndsAt:  sub.DiscountEndsAt,
+			CreatedAt:       sub.CreatedAt,
+			UpdatedAt:       sub.UpdatedAt,
+			IsPaused:        isPaused,
+		})
+	}
+
+	return dto.GetSubscriptionsResponse{
+		Subscriptions: response,
+		Total:         total,
+		Page:          query.Page,
+		Limit:         query.Limit,
+	}, nil
+}
+
+// CreateOrganizationSubscriptions subscribes members of an organization to a plan, billed to the
+// organization. Either every subscription is created or none, access to the organization is
+// checked by the caller.
+func (u *SubscriptionUsecase) CreateOrganizationSubscriptions(organizationId uuid.UUID, req dto.CreateOrganizationSubscriptionsRequest) (dto.CreateOrganizationSubscriptionsResponse, error) {
+	plans, err := u.plansRepo.GetSpecificPlans(entity.Plans{
+		ID: req.PlanId,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.CreateOrganizationSubscriptionsResponse{}, errors.New("plan not found")
+		}
+		return dto.CreateOrganizationSubscriptionsResponse{}, err
+	}
+
+	if plans.ArchivedAt != nil || !plans.IsAvailable {
+		return dto.CreateOrganizationSubscriptionsResponse{}, errors.New("plan is currently not available")
+	}
+
+	response := dto.CreateOrganizationSubscriptionsResponse{
+		SubscriptionIDs: []uuid.UUID{},
+	}
+	subscriptions := []entity.Subscription{}
+	for i, item := range req.Subscriptions {
+		memberId := uuid.MustParse(item.UserID)
+		if slices.ContainsFunc(subscriptions, func(s entity.Subscription) bool { return s.UserID == memberId }) {
+			return dto.CreateOrganizationSubscriptionsResponse{}, fmt.Errorf("subscription %d: member is listed more than once", i+1)
+		}
+
+		member, err := u.orgRepo.GetMembership(memberId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.CreateOrganizationSubscriptionsResponse{}, err
+		}
+
+		if member.OrganizationID != organizationId {
+			return dto.CreateOrganizationSubscriptionsResponse{}, fmt.Errorf("subscription %d: user is not a member of the organization", i+1)
+		}
+
+		checkedSub, err := u.subRepo.GetSpecific(entity.Subscription{
+			UserID: memberId,
+			PlanId: req.PlanId,
+			Status: constant.SubscriptionStatusActive,
 		})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.CreateOrganizationSubscriptionsResponse{}, err
+		}
+
+		if checkedSub.ID != uuid.Nil {
+			return dto.CreateOrganizationSubscriptionsResponse{}, fmt.Errorf("subscription %d: member already has an active subscription for this plan", i+1)
+		}
+
+		item.Name, item.PhoneNumber, err = u.withProfileContact(memberId, item.Name, item.PhoneNumber)
+		if err != nil {
+			return dto.CreateOrganizationSubscriptionsResponse{}, err
+		}
+
+		if item.PhoneNumber == "" {
+			return dto.CreateOrganizationSubscriptionsResponse{}, fmt.Errorf("subscription %d: phone number is required, the member has none in their profile", i+1)
+		}
+
+		allergens, err := menuUsecase.ResolveAllergens(u.menuRepo, item.AllergenIDs)
+		if err != nil {
+			return dto.CreateOrganizationSubscriptionsResponse{}, fmt.Errorf("subscription %d: %w", i+1, err)
+		}
+
+		subscription := entity.Subscription{
+			ID:             uuid.New(),
+			UserID:         memberId,
+			OrganizationID: &organizationId,
+			PlanId:         req.PlanId,
+			Name:           item.Name,
+			PhoneNumber:    item.PhoneNumber,
+			Mealtypes:      strings.Join(item.Mealtypes, ","),
+			DeliveryDays:   strings.Join(item.DeliveryDays, ","),
+			Allergens:      allergens,
+			AllergyNote:    item.AllergyNote,
+			Status:         constant.SubscriptionStatusActive,
+			TotalPrice:     subscriptionPrice(plans.Price, len(item.Mealtypes), len(item.DeliveryDays)),
+		}
+
+		subscriptions = append(subscriptions, subscription)
+		response.SubscriptionIDs = append(response.SubscriptionIDs, subscription.ID)
+		response.MonthlyTotal += subscription.TotalPrice
 	}
 
+	if err := u.subRepo.CreateSubscriptions(subscriptions); err != nil {
+		return dto.CreateOrganizationSubscriptionsResponse{}, err
+	}
+
+	response.MonthlyTotal = math.Round(response.MonthlyTotal*100) / 100
 	return response, nil
 }
 
+// ExportSubscriptions streams every subscription the user may read that matches the filters
+func (u *SubscriptionUsecase) ExportSubscriptions(ctx *fiber.Ctx, query dto.GetSubscriptionsQuery) (export.StreamFunc, error) {
+	condition, err := u.readableSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := toSubscriptionFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		err := w.WriteRow(
+			"id", "user_id", "user_email", "plan_id", "plan_name", "name", "phone_number", "mealtype", "delivery_days",
+			"allergens", "allergy_note", "total_price", "status", "pause_start_date", "pause_end_date", "cancelled_at",
+			"created_at", "updated_at",
+		)
+		if err != nil {
+			return err
+		}
+
+		return u.subRepo.StreamSubscriptions(condition, filter, func(batch []entity.Subscription) error {
+			for _, sub := range batch {
+				allergens := []string{}
+				for _, allergen := range sub.Allergens {
+					allergens = append(allergens, allergen.Name)
+				}
+
+				err := w.WriteRow(
+					sub.ID.String(), sub.UserID.String(), sub.User.Email, sub.PlanId, sub.Plans.Name, sub.Name,
+					sub.PhoneNumber, sub.Mealtypes, sub.DeliveryDays, strings.Join(allergens, ","), sub.AllergyNote,
+					sub.TotalPrice, sub.Status, formatDate(sub.PauseStartDate), formatDate(sub.PauseEndDate),
+					sub.CancelledAt, sub.CreatedAt, sub.UpdatedAt,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}, nil
+}
+
+// readableSubscriptions limits users without the read any permission to their own subscriptions
+func (u *SubscriptionUsecase) readableSubscriptions(ctx *fiber.Ctx) (entity.Subscription, error) {
+	userId := ctx.Locals("userId").(string)
+	role := ctx.Locals("role").(string)
+
+	canReadAny, err := u.permissionUsecase.HasPermission(role, constant.PermissionSubscriptionsReadAny)
+	if err != nil {
+		return entity.Subscription{}, err
+	}
+
+	condition := entity.Subscription{}
+	if !canReadAny {
+		condition.UserID = uuid.MustParse(userId)
+	}
+
+	return condition, nil
+}
+
+// SearchSubscriptions finds subscriptions for support by subscriber or customer name, customer
+// email or a fragment of the phone number, ignoring its formatting
+func (u *SubscriptionUsecase) SearchSubscriptions(query dto.SearchSubscriptionsQuery) ([]dto.SubscriptionSearchResult, error) {
+	if query.Limit <= 0 {
+		query.Limit = 20
+	}
+
+	search := strings.TrimSpace(query.Q)
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, search)
+
+	// Shorter digit runs would match most phone numbers
+	if len(digits) < 3 {
+		digits = ""
+	}
+
+	results, err := u.subRepo.SearchSubscriptions(search, digits, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		result := &results[i]
+		result.Highlights = map[string]string{}
+
+		fields := map[string]string{
+			"name":       result.Name,
+			"user_name":  result.UserName,
+			"user_email": result.UserEmail,
+		}
+		for field, value := range fields {
+			if highlighted, ok := highlightWords(value, search); ok {
+				result.Highlights[field] = highlighted
+			}
+		}
+
+		if highlighted, ok := highlightDigits(result.PhoneNumber, digits); ok {
+			result.Highlights["phone_number"] = highlighted
+		}
+	}
+
+	return results, nil
+}
+
 func (u *SubscriptionUsecase) GetSpecific(ctx *fiber.Ctx) (dto.GetSubscriptionResponse, error) {
 	userId := ctx.Locals("userId").(string)
 	role := ctx.Locals("role").(string)
@@ -100,27 +364,23 @@ func (u *SubscriptionUsecase) GetSpecific(ctx *fiber.Ctx) (dto.GetSubscriptionRe
 		return dto.GetSubscriptionResponse{}, errors.New("invalid subscription ID format")
 	}
 
-	subscription := entity.Subscription{
-		ID: subscriptionId,
-	}
-
-	if role != constant.RoleAdmin {
-		subscription.UserID = uuid.MustParse(userId)
+	canReadAny, err := u.permissionUsecase.HasPermission(role, constant.PermissionSubscriptionsReadAny)
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, err
 	}
 
-	result, err := u.subRepo.GetSpecific(subscription)
+	// Looked up by id only, organization admins read the subscriptions of their members too
+	result, err := u.subRepo.GetSpecific(entity.Subscription{
+		ID: subscriptionId,
+	})
 	if err != nil {
 		return dto.GetSubscriptionResponse{}, err
 	}
 
-	if result.UserID != uuid.MustParse(userId) && role != constant.RoleAdmin {
+	if result.UserID != uuid.MustParse(userId) && !canReadAny && !u.isOrganizationAdmin(userId, result.OrganizationID) {
 		return dto.GetSubscriptionResponse{}, errors.New("unauthorized access to subscription")
 	}
 
-	allergies := []string{}
-	if result.Allergies != "" {
-		allergies = strings.Split(result.Allergies, ",")
-	}
 	isPaused := false
 	if result.PauseStartDate != nil && result.PauseEndDate != nil {
 		if result.PauseStartDate.Before(time.Now()) && result.PauseEndDate.After(time.Now()) {
@@ -129,27 +389,32 @@ func (u *SubscriptionUsecase) GetSpecific(ctx *fiber.Ctx) (dto.GetSubscriptionRe
 	}
 
 	response := dto.GetSubscriptionResponse{
-		ID:     result.ID,
-		UserID: result.UserID,
+		ID:             result.ID,
+		UserID:         result.UserID,
+		OrganizationID: result.OrganizationID,
 		User: dto.GetUserResponse{
 			ID:    result.User.ID,
 			Name:  result.User.Name,
 			Email: result.User.Email,
 		},
-		PlanId:         result.PlanId,
-		Plans:          result.Plans,
-		Name:           result.Name,
-		PhoneNumber:    result.PhoneNumber,
-		Mealtypes:      strings.Split(result.Mealtypes, ","),
-		DeliveryDays:   strings.Split(result.DeliveryDays, ","),
-		Allergies:      allergies,
-		TotalPrice:     result.TotalPrice,
-		Status:         result.Status,
-		PauseStartDate: result.PauseStartDate,
-		PauseEndDate:   result.PauseEndDate,
-		CreatedAt:      result.CreatedAt,
-		UpdatedAt:      result.UpdatedAt,
-		IsPaused:       isPaused,
+		PlanId:          result.PlanId,
+		Plans:           result.Plans,
+		Name:            result.Name,
+		PhoneNumber:     result.PhoneNumber,
+		Mealtypes:       strings.Split(result.Mealtypes, ","),
+		DeliveryDays:    strings.Split(result.DeliveryDays, ","),
+		Allergens:       menuUsecase.ToAllergenResponses(result.Allergens),
+		AllergyNote:     result.AllergyNote,
+		TotalPrice:      result.TotalPrice,
+		Status:          result.Status,
+		PauseStartDate:  result.PauseStartDate,
+		PauseEndDate:    result.PauseEndDate,
+		CancelAt:        result.CancelAt,
+		DiscountPercent: result.DiscountPercent,
+		DiscountEndsAt:  result.DiscountEndsAt,
+		CreatedAt:       result.CreatedAt,
+		UpdatedAt:       result.UpdatedAt,
+		IsPaused:        isPaused,
 	}
 
 	return response, nil
@@ -169,6 +434,10 @@ func (u *SubscriptionUsecase) CreateSubscription(ctx *fiber.Ctx, req dto.CreateS
 		}
 	}
 
+	if plans.ArchivedAt != nil || !plans.IsAvailable {
+		return errors.New("plan is currently not available")
+	}
+
 	checkedSub, err := u.subRepo.GetSpecific(entity.Subscription{
 		UserID: uuid.MustParse(userId),
 		PlanId: req.PlanId,
@@ -183,10 +452,21 @@ func (u *SubscriptionUsecase) CreateSubscription(ctx *fiber.Ctx, req dto.CreateS
 		return errors.New("you already has an active subscription for this plan")
 	}
 
-	mealTypeLength := len(req.Mealtypes)
-	deliveryDaysLength := len(req.DeliveryDays)
+	req.Name, req.PhoneNumber, err = u.withProfileContact(uuid.MustParse(userId), req.Name, req.PhoneNumber)
+	if err != nil {
+		return err
+	}
 
-	totalPrice := plans.Price * float64(mealTypeLength) * float64(deliveryDaysLength) * constant.SubscriptionTAX
+	if req.PhoneNumber == "" {
+		return errors.New("phone number is required, fill it in or save it in your profile")
+	}
+
+	allergens, err := menuUsecase.ResolveAllergens(u.menuRepo, req.AllergenIDs)
+	if err != nil {
+		return err
+	}
+
+	totalPrice := subscriptionPrice(plans.Price, len(req.Mealtypes), len(req.DeliveryDays))
 
 	subscription := entity.Subscription{
 		ID:           uuid.New(),
@@ -196,7 +476,8 @@ func (u *SubscriptionUsecase) CreateSubscription(ctx *fiber.Ctx, req dto.CreateS
 		PhoneNumber:  req.PhoneNumber,
 		Mealtypes:    strings.Join(req.Mealtypes, ","),
 		DeliveryDays: strings.Join(req.DeliveryDays, ","),
-		Allergies:    strings.Join(req.Allergies, ","),
+		Allergens:    allergens,
+		AllergyNote:  req.AllergyNote,
 		Status:       constant.SubscriptionStatusActive,
 		TotalPrice:   totalPrice,
 	}
@@ -209,26 +490,11 @@ func (u *SubscriptionUsecase) CreateSubscription(ctx *fiber.Ctx, req dto.CreateS
 }
 
 func (u *SubscriptionUsecase) UpdateSubscription(ctx *fiber.Ctx, req dto.UpdateSubscriptionRequest) error {
-	userId := ctx.Locals("userId").(string)
-	role := ctx.Locals("role").(string)
-	param := ctx.Params("id")
-
-	subscriptionId, err := uuid.Parse(param)
-	if err != nil {
-		return errors.New("invalid subscription ID format")
-	}
-
-	subscription, err := u.subRepo.GetSpecific(entity.Subscription{
-		ID: subscriptionId,
-	})
+	subscription, err := u.getWritableSubscription(ctx)
 	if err != nil {
 		return err
 	}
 
-	if subscription.UserID != uuid.MustParse(userId) && role != constant.RoleAdmin {
-		return errors.New("unauthorized access to subscription")
-	}
-
 	if subscription.Status == constant.SubscriptionStatusCancelled {
 		return errors.New("subscription is already cancelled")
 	}
@@ -284,16 +550,211 @@ func (u *SubscriptionUsecase) UpdateSubscription(ctx *fiber.Ctx, req dto.UpdateS
 		PauseEndDate:   pauseEndDate,
 	}
 
-	if err := u.subRepo.UpdateSubscription(subUpdate); err != nil {
+	var allergens []entity.Allergen
+	if req.AllergenIDs != nil {
+		allergens, err = menuUsecase.ResolveAllergens(u.menuRepo, req.AllergenIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return u.subRepo.Transaction(func(tx *gorm.DB) error {
+		repo := u.subRepo.WithTx(tx)
+		if err := repo.UpdateSubscription(subUpdate, req.AllergyNote); err != nil {
+			return err
+		}
+
+		if req.AllergenIDs == nil {
+			return nil
+		}
+
+		return repo.ReplaceSubscriptionAllergens(subscription.ID, allergens)
+	})
+}
+
+// GetRetentionOffer returns the offer to show a customer who wants to cancel for the given reason
+func (u *SubscriptionUsecase) GetRetentionOffer(ctx *fiber.Ctx, query dto.GetRetentionOfferQuery) (dto.RetentionOfferResponse, error) {
+	subscription, err := u.getWritableSubscription(ctx)
+	if err != nil {
+		return dto.RetentionOfferResponse{}, err
+	}
+
+	if subscription.Status == constant.SubscriptionStatusCancelled {
+		return dto.RetentionOfferResponse{}, errors.New("subscription is already cancelled")
+	}
+
+	offer, _ := retentionOffer(subscription, query.Reason, time.Now())
+	return offer, nil
+}
+
+// CancelSubscription cancels the subscription now or at the end of its billing period, or applies
+// the retention offer for the reason instead when the customer accepts it
+func (u *SubscriptionUsecase) CancelSubscription(ctx *fiber.Ctx, req dto.CancelSubscriptionRequest) (dto.CancelSubscriptionResponse, error) {
+	subscription, err := u.getWritableSubscription(ctx)
+	if err != nil {
+		return dto.CancelSubscriptionResponse{}, err
+	}
+
+	if subscription.Status == constant.SubscriptionStatusCancelled {
+		return dto.CancelSubscriptionResponse{}, errors.New("subscription is already cancelled")
+	}
+
+	now := time.Now()
+	offer, hasOffer := retentionOffer(subscription, req.Reason, now)
+	cancellation := entity.SubscriptionCancellation{
+		SubscriptionID: subscription.ID,
+		Reason:         req.Reason,
+		Note:           strings.TrimSpace(req.Note),
+		OfferType:      offer.Type,
+	}
+
+	if req.AcceptOffer {
+		if !hasOffer {
+			return dto.CancelSubscriptionResponse{}, errors.New("no retention offer is available for this subscription")
+		}
+
+		retained := entity.Subscription{
+			ID:               subscription.ID,
+			TotalPrice:       subscription.TotalPrice,
+			DiscountPercent:  offer.DiscountPercent,
+			DiscountEndsAt:   offer.DiscountEndsAt,
+			PauseStartDate:   offer.PauseStartDate,
+			PauseEndDate:     offer.PauseEndDate,
+			RetentionOfferAt: &now,
+		}
+
+		if err := u.subRepo.RetainSubscription(retained, cancellation); err != nil {
+			return dto.CancelSubscriptionResponse{}, err
+		}
+
+		return dto.CancelSubscriptionResponse{
+			Outcome: constant.CancellationOutcomeRetained,
+			Offer:   &offer,
+		}, nil
+	}
+
+	response := dto.CancelSubscriptionResponse{
+		Outcome:     constant.CancellationOutcomeCancelled,
+		EffectiveAt: &now,
+	}
+
+	if req.AtPeriodEnd {
+		periodEnd := billingPeriodEnd(subscription.CreatedAt, now)
+		cancellation.EffectiveAt = &periodEnd
+		response.Outcome = constant.CancellationOutcomeScheduled
+		response.EffectiveAt = &periodEnd
+	}
+
+	if err := u.subRepo.CancelSubscription(cancellation); err != nil {
+		return dto.CancelSubscriptionResponse{}, err
+	}
+
+	return response, nil
+}
+
+// RevokeCancellation keeps a subscription that is scheduled to be cancelled
+func (u *SubscriptionUsecase) RevokeCancellation(ctx *fiber.Ctx) error {
+	subscription, err := u.getWritableSubscription(ctx)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	if subscription.Status == constant.SubscriptionStatusCancelled || subscription.CancelAt == nil {
+		return errors.New("subscription has no scheduled cancellation")
+	}
+
+	return u.subRepo.RevokeScheduledCancellation(subscription.ID)
+}
+
+// ReactivateSubscription restores a cancelled subscription with its history, priced at the
+// current price of the plan
+func (u *SubscriptionUsecase) ReactivateSubscription(ctx *fiber.Ctx) error {
+	subscription, err := u.getWritableSubscription(ctx)
+	if err != nil {
+		return err
+	}
+
+	if subscription.Status != constant.SubscriptionStatusCancelled {
+		return errors.New("subscription is not cancelled")
+	}
+
+	// Subscriptions of deleted accounts have their contact details removed
+	if subscription.PhoneNumber == "" {
+		return errors.New("subscription can no longer be reactivated")
+	}
+
+	plans, err := u.plansRepo.GetSpecificPlans(entity.Plans{
+		ID: subscription.PlanId,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("plan not found")
+		}
+		return err
+	}
+
+	if plans.ArchivedAt != nil || !plans.IsAvailable {
+		return errors.New("plan is currently not available")
+	}
+
+	checkedSub, err := u.subRepo.GetSpecific(entity.Subscription{
+		UserID: subscription.UserID,
+		PlanId: subscription.PlanId,
+		Status: constant.SubscriptionStatusActive,
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	// The same rule as when subscribing, one active subscription per plan
+	if checkedSub.ID != uuid.Nil {
+		return errors.New("there is already an active subscription for this plan")
+	}
+
+	totalPrice := subscriptionPrice(
+		plans.Price,
+		len(strings.Split(subscription.Mealtypes, ",")),
+		len(strings.Split(subscription.DeliveryDays, ",")),
+	)
+
+	return u.subRepo.ReactivateSubscription(subscription.ID, totalPrice)
 }
 
-func (u *SubscriptionUsecase) GetSubscriptionsReport(ctx *fiber.Ctx) (dto.GetSubscriptionReportResponse, error) {
-	queryStartDate := ctx.Query("start_date")
-	queryEndDate := ctx.Query("end_date")
+// RunScheduledCancellations records the retention discounts that ended and cancels the subscriptions
+// whose billing period ended after a cancellation was scheduled, right away and then on every tick
+// of the interval until the context is done
+func (u *SubscriptionUsecase) RunScheduledCancellations(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Discounts ending before a cancellation are recorded first, so churn is counted at the full price
+		now := time.Now()
+		ended, err := u.subRepo.EndDueDiscounts(now)
+		if err != nil {
+			log.Printf("[subscription] failed to record ended retention discounts: %v\n", err)
+		} else if ended > 0 {
+			log.Printf("[subscription] recorded the end of %d retention discounts\n", ended)
+		}
+
+		cancelled, err := u.subRepo.CancelDueSubscriptions(now)
+		if err != nil {
+			log.Printf("[subscription] failed to apply scheduled cancellations: %v\n", err)
+		} else if cancelled > 0 {
+			log.Printf("[subscription] cancelled %d subscriptions at the end of their billing period\n", cancelled)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *SubscriptionUsecase) GetSubscriptionsReport(ctx *fiber.Ctx, query dto.GetSubscriptionReportQuery) (dto.GetSubscriptionReportResponse, error) {
+	queryStartDate := query.StartDate
+	queryEndDate := query.EndDate
 
 	startDate := new(time.Time)
 	endDate := new(time.Time)
@@ -322,31 +783,17 @@ func (u *SubscriptionUsecase) GetSubscriptionsReport(ctx *fiber.Ctx) (dto.GetSub
 		endDate = &daysEnd
 	}
 
-	// If no date range is provided, use the current date as both start and end date
-	getActiveSubscriptions, err := u.subRepo.GetActiveSubscriptions(nil, nil)
+	allActiveSubscriptions, totalRevenue, err := u.subRepo.GetActiveSubscriptionTotals(nil, nil)
 	if err != nil {
 		return dto.GetSubscriptionReportResponse{}, err
 	}
 
-	// Get active subscriptions within the specified date range
-	getActiveSubscriptionsByDate, err := u.subRepo.GetActiveSubscriptions(startDate, endDate)
+	// Active subscriptions created within the specified date range
+	activeSubscriptionsByDate, totalRevenueByDate, err := u.subRepo.GetActiveSubscriptionTotals(startDate, endDate)
 	if err != nil {
 		return dto.GetSubscriptionReportResponse{}, err
 	}
 
-	allActiveSubscriptions := len(getActiveSubscriptions)
-	activeSubscriptionsByDate := len(getActiveSubscriptionsByDate)
-
-	totalRevenue := 0.0
-	for _, sub := range getActiveSubscriptions {
-		totalRevenue += sub.TotalPrice
-	}
-
-	totalRevenueByDate := 0.0
-	for _, sub := range getActiveSubscriptionsByDate {
-		totalRevenueByDate += sub.TotalPrice
-	}
-
 	return dto.GetSubscriptionReportResponse{
 		ActiveSubscriptionsByDate: activeSubscriptionsByDate,
 		TotalRevenue:              totalRevenue,
@@ -354,3 +801,454 @@ func (u *SubscriptionUsecase) GetSubscriptionsReport(ctx *fiber.Ctx) (dto.GetSub
 		TotalRevenueByDate:        totalRevenueByDate,
 	}, nil
 }
+
+func (u *SubscriptionUsecase) ExportSubscriptionsReport(ctx *fiber.Ctx, query dto.GetSubscriptionReportQuery) (export.StreamFunc, error) {
+	report, err := u.GetSubscriptionsReport(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w export.Writer) error {
+		err := w.WriteRow("start_date", "end_date", "total_active_subscriptions", "total_revenue", "active_subscriptions_by_date", "total_revenue_by_date")
+		if err != nil {
+			return err
+		}
+
+		return w.WriteRow(
+			query.StartDate, query.EndDate, report.TotalActiveSubscriptions, report.TotalRevenue,
+			report.ActiveSubscriptionsByDate, report.TotalRevenueByDate,
+		)
+	}, nil
+}
+
+// GetAllergenConflicts lists the dishes on the subscription's weekly menu that contain one of its declared allergens
+func (u *SubscriptionUsecase) GetAllergenConflicts(ctx *fiber.Ctx, query dto.GetWeeklyMenuQuery) ([]dto.AllergenConflict, error) {
+	subscription, err := u.GetSpecific(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	date := time.Now()
+	if query.Week != "" {
+		parsedDate, err := time.Parse("02-01-2006", query.Week)
+		if err != nil {
+			return nil, errors.New("invalid week format, expected dd-mm-yyyy")
+		}
+		date = parsedDate
+	}
+
+	weekStart := utils.StartOfWeek(date)
+	return u.menuRepo.GetAllergenConflicts(weekStart, weekStart.AddDate(0, 0, 6), &subscription.ID)
+}
+
+func (u *SubscriptionUsecase) GetMealSelections(ctx *fiber.Ctx, query dto.GetMealSelectionsQuery) ([]dto.MealSelectionResponse, error) {
+	subscription, err := u.GetSpecific(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	date := time.Now()
+	if query.Week != "" {
+		parsedDate, err := time.Parse("02-01-2006", query.Week)
+		if err != nil {
+			return nil, errors.New("invalid week format, expected dd-mm-yyyy")
+		}
+		date = parsedDate
+	}
+	weekStart := utils.StartOfWeek(date)
+
+	items, err := u.menuRepo.GetMenuItems(subscription.PlanId, weekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	selections, err := u.subRepo.GetMealSelections(subscription.ID, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return nil, err
+	}
+
+	declaredAllergens := []uuid.UUID{}
+	for _, allergen := range subscription.Allergens {
+		declaredAllergens = append(declaredAllergens, allergen.ID)
+	}
+
+	response := []dto.MealSelectionResponse{}
+	for i, day := range constant.DeliveryDays {
+		if !slices.Contains(subscription.DeliveryDays, day) {
+			continue
+		}
+
+		deliveryDate := weekStart.AddDate(0, 0, i)
+		deadline := mealSelectionDeadline(deliveryDate)
+
+		for _, mealtype := range constant.Mealtypes {
+			if !slices.Contains(subscription.Mealtypes, mealtype) {
+				continue
+			}
+
+			meal := dto.MealSelectionResponse{
+				Date:        deliveryDate,
+				DeliveryDay: day,
+				Mealtype:    mealtype,
+				Deadline:    deadline,
+				IsLocked:    time.Now().After(deadline),
+				Options:     []dto.MealOptionResponse{},
+			}
+
+			var picked *uuid.UUID
+			for _, selection := range selections {
+				if selection.DeliveryDate.Equal(deliveryDate) && selection.Mealtype == mealtype {
+					picked = &selection.DishID
+				}
+			}
+
+			for _, item := range items {
+				if item.DeliveryDay != day || item.Mealtype != mealtype {
+					continue
+				}
+
+				conflicting := []string{}
+				for _, allergen := range item.Dish.Allergens {
+					if slices.Contains(declaredAllergens, allergen.ID) {
+						conflicting = append(conflicting, allergen.Name)
+					}
+				}
+
+				meal.Options = append(meal.Options, dto.MealOptionResponse{
+					IsDefault:            item.IsDefault,
+					Dish:                 menuUsecase.ToDishResponse(item.Dish),
+					ConflictingAllergens: conflicting,
+				})
+
+				if picked != nil && *picked == item.DishID {
+					meal.DishID = &item.DishID
+				}
+			}
+
+			// The pick is dropped when the dish was taken off the menu, the default is served instead
+			if meal.DishID == nil {
+				for _, item := range items {
+					if item.DeliveryDay == day && item.Mealtype == mealtype && item.IsDefault {
+						meal.DishID = &item.DishID
+						meal.IsDefaultPick = true
+					}
+				}
+			}
+
+			response = append(response, meal)
+		}
+	}
+
+	return response, nil
+}
+
+func (u *SubscriptionUsecase) UpdateMealSelections(ctx *fiber.Ctx, req dto.UpdateMealSelectionsRequest) error {
+	subscription, err := u.getWritableSubscription(ctx)
+	if err != nil {
+		return err
+	}
+
+	if subscription.Status != constant.SubscriptionStatusActive {
+		return errors.New("subscription is not active")
+	}
+
+	mealtypes := strings.Split(subscription.Mealtypes, ",")
+	deliveryDays := strings.Split(subscription.DeliveryDays, ",")
+	menus := map[time.Time][]entity.MenuItem{}
+
+	selections := make([]entity.MealSelection, 0, len(req.Selections))
+	for _, selection := range req.Selections {
+		deliveryDate, err := time.Parse("02-01-2006", selection.Date)
+		if err != nil {
+			return errors.New("invalid date format, expected dd-mm-yyyy")
+		}
+
+		day := deliveryDate.Weekday().String()
+		if !slices.Contains(deliveryDays, day) || !slices.Contains(mealtypes, selection.Mealtype) {
+			return fmt.Errorf("no %s delivery on %s", selection.Mealtype, selection.Date)
+		}
+
+		if time.Now().After(mealSelectionDeadline(deliveryDate)) {
+			return fmt.Errorf("meal selection for %s is closed", selection.Date)
+		}
+
+		weekStart := utils.StartOfWeek(deliveryDate)
+		items, ok := menus[weekStart]
+		if !ok {
+			items, err = u.menuRepo.GetMenuItems(subscription.PlanId, weekStart)
+			if err != nil {
+				return err
+			}
+			menus[weekStart] = items
+		}
+
+		dishId := uuid.MustParse(selection.DishID)
+		offered := slices.ContainsFunc(items, func(item entity.MenuItem) bool {
+			return item.DeliveryDay == day && item.Mealtype == selection.Mealtype && item.DishID == dishId
+		})
+		if !offered {
+			return fmt.Errorf("dish is not on the %s menu of %s", selection.Mealtype, selection.Date)
+		}
+
+		// A later pick for the same meal wins
+		selections = slices.DeleteFunc(selections, func(s entity.MealSelection) bool {
+			return s.DeliveryDate.Equal(deliveryDate) && s.Mealtype == selection.Mealtype
+		})
+
+		selections = append(selections, entity.MealSelection{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			DeliveryDate:   deliveryDate,
+			Mealtype:       selection.Mealtype,
+			DishID:         dishId,
+		})
+	}
+
+	return u.subRepo.SaveMealSelections(selections)
+}
+
+// getWritableSubscription loads the subscription in the route parameter when it
+// belongs to the current user or the user may update any subscription
+func (u *SubscriptionUsecase) getWritableSubscription(ctx *fiber.Ctx) (entity.Subscription, error) {
+	userId := ctx.Locals("userId").(string)
+	role := ctx.Locals("role").(string)
+
+	subscriptionId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return entity.Subscription{}, errors.New("invalid subscription ID format")
+	}
+
+	subscription, err := u.subRepo.GetSpecific(entity.Subscription{
+		ID: subscriptionId,
+	})
+	if err != nil {
+		return entity.Subscription{}, err
+	}
+
+	canWriteAny, err := u.permissionUsecase.HasPermission(role, constant.PermissionSubscriptionsWriteAny)
+	if err != nil {
+		return entity.Subscription{}, err
+	}
+
+	if subscription.UserID != uuid.MustParse(userId) && !canWriteAny && !u.isOrganizationAdmin(userId, subscription.OrganizationID) {
+		return entity.Subscription{}, errors.New("unauthorized access to subscription")
+	}
+
+	return subscription, nil
+}
+
+// withProfileContact fills a missing name or phone number from the contact details saved in the
+// user's profile, the phone number stays empty when the profile has none either
+func (u *SubscriptionUsecase) withProfileContact(userId uuid.UUID, name string, phoneNumber string) (string, string, error) {
+	if name != "" && phoneNumber != "" {
+		return name, phoneNumber, nil
+	}
+
+	user, err := u.userRepo.GetSpecificUser(entity.User{
+		ID: userId,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	if name == "" {
+		name = user.ContactName
+	}
+
+	if name == "" {
+		name = user.Name
+	}
+
+	if phoneNumber == "" {
+		phoneNumber = user.PhoneNumber
+	}
+
+	return name, phoneNumber, nil
+}
+
+// isOrganizationAdmin reports whether the user administers the organization a subscription is
+// billed to, organization admins manage the subscriptions of their members
+func (u *SubscriptionUsecase) isOrganizationAdmin(userId string, organizationId *uuid.UUID) bool {
+	if organizationId == nil {
+		return false
+	}
+
+	member, err := u.orgRepo.GetMembership(uuid.MustParse(userId))
+	if err != nil {
+		return false
+	}
+
+	return member.OrganizationID == *organizationId && member.Role == constant.OrganizationRoleAdmin
+}
+
+// subscriptionPrice is the monthly price of a plan for the chosen meal types and delivery days
+func subscriptionPrice(planPrice float64, mealtypes int, deliveryDays int) float64 {
+	return planPrice * float64(mealtypes) * float64(deliveryDays) * constant.SubscriptionTAX
+}
+
+// retentionOffer builds the offer for a cancellation reason. Subscriptions that already accepted
+// an offer, or are scheduled to be cancelled, get none, and neither do paused ones a pause.
+func retentionOffer(subscription entity.Subscription, reason string, now time.Time) (dto.RetentionOfferResponse, bool) {
+	offerType := constant.RetentionOffers[reason]
+	if offerType == "" || subscription.RetentionOfferAt != nil || subscription.CancelAt != nil {
+		return dto.RetentionOfferResponse{}, false
+	}
+
+	switch offerType {
+	case constant.RetentionOfferDiscount:
+		endsAt := now.AddDate(0, constant.RetentionDiscountMonths, 0)
+		price := subscription.TotalPrice * (100 - constant.RetentionDiscountPercent) / 100
+
+		return dto.RetentionOfferResponse{
+			Type:            offerType,
+			DiscountPercent: constant.RetentionDiscountPercent,
+			DiscountedPrice: math.Round(price*100) / 100,
+			DiscountEndsAt:  &endsAt,
+		}, true
+	case constant.RetentionOfferPause:
+		if subscription.PauseEndDate != nil && subscription.PauseEndDate.After(now) {
+			return dto.RetentionOfferResponse{}, false
+		}
+
+		// Pause dates are stored as UTC midnight, like the dates parsed from requests
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 0, 7*constant.RetentionPauseWeeks)
+
+		return dto.RetentionOfferResponse{
+			Type:           offerType,
+			PauseStartDate: &start,
+			PauseEndDate:   &end,
+		}, true
+	}
+
+	return dto.RetentionOfferResponse{}, false
+}
+
+// billingPeriodEnd is the first monthly anniversary of the subscription after now
+func billingPeriodEnd(createdAt time.Time, now time.Time) time.Time {
+	months := 1
+	end := createdAt.AddDate(0, months, 0)
+	for !end.After(now) {
+		months++
+		end = createdAt.AddDate(0, months, 0)
+	}
+
+	return end
+}
+
+func toSubscriptionFilter(query dto.GetSubscriptionsQuery) (dto.SubscriptionFilter, error) {
+	filter := dto.SubscriptionFilter{
+		Status:      query.Status,
+		PlanId:      query.PlanId,
+		Mealtype:    query.Mealtype,
+		DeliveryDay: query.DeliveryDay,
+		Search:      strings.TrimSpace(query.Search),
+		Sort:        query.Sort,
+		Limit:       query.Limit,
+		Page:        query.Page,
+	}
+
+	if query.Paused != "" {
+		paused := query.Paused == "true"
+		filter.Paused = &paused
+	}
+
+	if query.CreatedFrom != "" {
+		createdFrom, err := time.Parse("02-01-2006", query.CreatedFrom)
+		if err != nil {
+			return dto.SubscriptionFilter{}, errors.New("invalid created_from format, expected dd-mm-yyyy")
+		}
+		filter.CreatedFrom = &createdFrom
+	}
+
+	if query.CreatedTo != "" {
+		createdTo, err := time.Parse("02-01-2006", query.CreatedTo)
+		if err != nil {
+			return dto.SubscriptionFilter{}, errors.New("invalid created_to format, expected dd-mm-yyyy")
+		}
+		filter.CreatedTo = &createdTo
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedTo.Before(*filter.CreatedFrom) {
+		return dto.SubscriptionFilter{}, errors.New("created_to must not be before created_from")
+	}
+
+	return filter, nil
+}
+
+// highlightWords marks every occurrence of the search words in the value, ignoring case. Values
+// found by similarity alone may not contain any of the words.
+func highlightWords(value string, search string) (string, bool) {
+	words := []string{}
+	for _, word := range strings.Fields(search) {
+		words = append(words, regexp.QuoteMeta(word))
+	}
+
+	if len(words) == 0 {
+		return "", false
+	}
+
+	pattern := regexp.MustCompile("(?i)" + strings.Join(words, "|"))
+	matches := pattern.FindAllStringIndex(value, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	return markRanges(value, matches), true
+}
+
+// highlightDigits marks the digits fragment in a phone number, including the spaces or dashes
+// between its digits
+func highlightDigits(phoneNumber string, digits string) (string, bool) {
+	if digits == "" {
+		return "", false
+	}
+
+	var phoneDigits strings.Builder
+	positions := []int{}
+	for i, r := range phoneNumber {
+		if r >= '0' && r <= '9' {
+			phoneDigits.WriteRune(r)
+			positions = append(positions, i)
+		}
+	}
+
+	start := strings.Index(phoneDigits.String(), digits)
+	if start == -1 {
+		return "", false
+	}
+
+	end := positions[start+len(digits)-1] + 1
+	return markRanges(phoneNumber, [][]int{{positions[start], end}}), true
+}
+
+// markRanges wraps the byte ranges of the value in <mark> tags and escapes everything else
+func markRanges(value string, ranges [][]int) string {
+	var builder strings.Builder
+	last := 0
+	for _, r := range ranges {
+		builder.WriteString(html.EscapeString(value[last:r[0]]))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(value[r[0]:r[1]]))
+		builder.WriteString("</mark>")
+		last = r[1]
+	}
+	builder.WriteString(html.EscapeString(value[last:]))
+
+	return builder.String()
+}
+
+// formatDate formats the date only columns, which are stored as UTC midnight
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+
+	return date.Format("2006-01-02")
+}
+
+// mealSelectionDeadline is the last moment the dishes of a delivery date can be picked
+func mealSelectionDeadline(deliveryDate time.Time) time.Time {
+	startOfDay := time.Date(deliveryDate.Year(), deliveryDate.Month(), deliveryDate.Day(), 0, 0, 0, 0, time.Local)
+	return startOfDay.Add(-constant.MealSelectionCutoff)
+}
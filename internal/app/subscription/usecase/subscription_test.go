@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	orgRepo "github.com/jevvonn/sea-catering-be/internal/app/organization/repository"
+	permissionUsecase "github.com/jevvonn/sea-catering-be/internal/app/permission/usecase"
+	subRepo "github.com/jevvonn/sea-catering-be/internal/app/subscription/repository"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type fakeSubscriptionRepo struct {
+	subRepo.SubscriptionPostgreSQLItf
+
+	mu            sync.Mutex
+	calls         []string
+	subscriptions []entity.Subscription
+}
+
+// GetSpecific matches the non zero fields of cond, like a gorm struct condition
+func (r *fakeSubscriptionRepo) GetSpecific(cond entity.Subscription) (entity.Subscription, error) {
+	for _, subscription := range r.subscriptions {
+		if cond.ID != uuid.Nil && subscription.ID != cond.ID {
+			continue
+		}
+		if cond.UserID != uuid.Nil && subscription.UserID != cond.UserID {
+			continue
+		}
+		return subscription, nil
+	}
+
+	return entity.Subscription{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSubscriptionRepo) EndDueDiscounts(now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "discounts")
+	return 0, nil
+}
+
+func (r *fakeSubscriptionRepo) CancelDueSubscriptions(now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, "cancellations")
+	return 0, nil
+}
+
+func TestRunScheduledCancellationsStopsWithContext(t *testing.T) {
+	repo := &fakeSubscriptionRepo{}
+	u := &SubscriptionUsecase{subRepo: repo}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		u.RunScheduledCancellations(ctx, time.Hour)
+		close(done)
+	}()
+
+	// The first run happens right away, then the job waits for the next tick
+	deadline := time.After(time.Second)
+	for {
+		repo.mu.Lock()
+		calls := len(repo.calls)
+		repo.mu.Unlock()
+		if calls == 2 {
+			break
+		}
+
+		select {
+		case <-deadline:
+			t.Fatal("the first run did not happen")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the job kept running after the context was cancelled")
+	}
+
+	// Discounts that ended are recorded before the cancellations due at the same time
+	if repo.calls[0] != "discounts" || repo.calls[1] != "cancellations" {
+		t.Fatalf("calls = %v", repo.calls)
+	}
+}
+
+type fakeOrgRepo struct {
+	orgRepo.OrganizationPostgreSQLItf
+
+	members []entity.OrganizationMember
+}
+
+func (r *fakeOrgRepo) GetMembership(userId uuid.UUID) (entity.OrganizationMember, error) {
+	for _, member := range r.members {
+		if member.UserID == userId {
+			return member, nil
+		}
+	}
+
+	return entity.OrganizationMember{}, gorm.ErrRecordNotFound
+}
+
+type fakeUserRepo struct {
+	userRepo.UserPostgreSQLItf
+
+	user    entity.User
+	lookups int
+}
+
+func (r *fakeUserRepo) GetSpecificUser(cond entity.User) (entity.User, error) {
+	r.lookups++
+	if cond.ID != r.user.ID {
+		return entity.User{}, gorm.ErrRecordNotFound
+	}
+
+	return r.user, nil
+}
+
+type fakePermissionUsecase struct {
+	permissionUsecase.PermissionUsecaseItf
+
+	permissions map[string][]string
+}
+
+func (u *fakePermissionUsecase) HasPermission(role string, permission string) (bool, error) {
+	return slices.Contains(u.permissions[role], permission), nil
+}
+
+// call runs fn inside a request to /:id as a user with the given role
+func call(t *testing.T, id uuid.UUID, userId uuid.UUID, role string, fn func(ctx *fiber.Ctx) error) error {
+	t.Helper()
+
+	var err error
+	app := fiber.New()
+	app.Get("/:id", func(ctx *fiber.Ctx) error {
+		ctx.Locals("userId", userId.String())
+		ctx.Locals("role", role)
+		err = fn(ctx)
+		return nil
+	})
+
+	if _, testErr := app.Test(httptest.NewRequest(fiber.MethodGet, "/"+id.String(), nil)); testErr != nil {
+		t.Fatal(testErr)
+	}
+
+	return err
+}
+
+func TestGetSpecificAuthorization(t *testing.T) {
+	organizationId := uuid.New()
+	owner, orgAdmin, orgMember, stranger, admin := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	subscription := entity.Subscription{
+		ID:             uuid.New(),
+		UserID:         owner,
+		OrganizationID: &organizationId,
+		Mealtypes:      "Lunch",
+		DeliveryDays:   "Monday",
+	}
+
+	u := &SubscriptionUsecase{
+		subRepo: &fakeSubscriptionRepo{subscriptions: []entity.Subscription{subscription}},
+		orgRepo: &fakeOrgRepo{members: []entity.OrganizationMember{
+			{OrganizationID: organizationId, UserID: owner, Role: constant.OrganizationRoleMember},
+			{OrganizationID: organizationId, UserID: orgAdmin, Role: constant.OrganizationRoleAdmin},
+			{OrganizationID: organizationId, UserID: orgMember, Role: constant.OrganizationRoleMember},
+		}},
+		permissionUsecase: &fakePermissionUsecase{permissions: map[string][]string{
+			constant.RoleAdmin: {constant.PermissionSubscriptionsReadAny},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		userId  uuid.UUID
+		role    string
+		allowed bool
+	}{
+		{"owner", owner, constant.RoleUser, true},
+		{"organization admin", orgAdmin, constant.RoleUser, true},
+		{"read any", admin, constant.RoleAdmin, true},
+		{"organization member", orgMember, constant.RoleUser, false},
+		{"stranger", stranger, constant.RoleUser, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := call(t, subscription.ID, tt.userId, tt.role, func(ctx *fiber.Ctx) error {
+				response, err := u.GetSpecific(ctx)
+				if err == nil && response.ID != subscription.ID {
+					t.Fatalf("got subscription %s, want %s", response.ID, subscription.ID)
+				}
+				return err
+			})
+			if (err == nil) != tt.allowed {
+				t.Fatalf("err = %v, want allowed %v", err, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestWithProfileContact(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name        string
+		user        entity.User
+		inName      string
+		inPhone     string
+		wantName    string
+		wantPhone   string
+		wantLookups int
+	}{
+		{"both given", entity.User{Name: "Jane"}, "John", "0812", "John", "0812", 0},
+		{"contact name first", entity.User{Name: "Jane", ContactName: "Jane Doe", PhoneNumber: "0813"}, "", "", "Jane Doe", "0813", 1},
+		{"account name last", entity.User{Name: "Jane", PhoneNumber: "0813"}, "", "0812", "Jane", "0812", 1},
+		{"no phone anywhere", entity.User{Name: "Jane"}, "John", "", "John", "", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.user.ID = userId
+			users := &fakeUserRepo{user: tt.user}
+			u := &SubscriptionUsecase{userRepo: users}
+
+			name, phone, err := u.withProfileContact(userId, tt.inName, tt.inPhone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != tt.wantName || phone != tt.wantPhone || users.lookups != tt.wantLookups {
+				t.Fatalf("got %q / %q after %d lookups, want %q / %q after %d", name, phone, users.lookups, tt.wantName, tt.wantPhone, tt.wantLookups)
+			}
+		})
+	}
+}
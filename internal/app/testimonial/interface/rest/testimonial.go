@@ -2,9 +2,13 @@ package rest
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/jevvonn/sea-catering-be/internal/app/testimonial/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/i18n"
 	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
 	"github.com/jevvonn/sea-catering-be/internal/models"
 )
 
@@ -15,23 +19,56 @@ type TestimonialHandler struct {
 
 func NewTestimonialHandler(
 	router fiber.Router,
+	mw *middleware.Middleware,
 	testimonialUsacase usecase.TestimonialUsecaseItf,
 	validator validator.ValidationService,
 ) {
 	handler := TestimonialHandler{testimonialUsacase, validator}
 
 	router.Get("/testimonials", handler.GetTestimonials)
-	router.Post("/testimonials", handler.CreateTestimonial)
+	router.Get("/testimonials/challenge", handler.CreateChallenge)
+	router.Post("/testimonials", submitLimiter(), mw.OptionalAuthenticated, handler.CreateTestimonial)
+	router.Get("/testimonials/stats", handler.GetTestimonialStats)
+	router.Get("/testimonials/mine", mw.Authenticated, handler.GetMyTestimonials)
+	router.Put("/testimonials/:id", mw.Authenticated, handler.UpdateTestimonial)
+	router.Get("/testimonials/moderation", mw.Authenticated, mw.RequirePermission(constant.PermissionTestimonialsModerate), handler.GetModerationQueue)
+	router.Patch("/testimonials/:id/status", mw.Authenticated, mw.RequirePermission(constant.PermissionTestimonialsModerate), handler.ModerateTestimonial)
+	router.Delete("/testimonials/:id", mw.Authenticated, handler.DeleteTestimonial)
+	router.Put("/testimonials/:id/reply", mw.Authenticated, mw.RequirePermission(constant.PermissionTestimonialsReply), handler.ReplyTestimonial)
+	router.Delete("/testimonials/:id/reply", mw.Authenticated, mw.RequirePermission(constant.PermissionTestimonialsReply), handler.DeleteReply)
+}
+
+// submitLimiter throttles testimonial submissions per IP address on top of the global limiter
+func submitLimiter() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        constant.TestimonialSubmitLimit,
+		Expiration: constant.TestimonialSubmitWindow,
+		KeyGenerator: func(ctx *fiber.Ctx) string {
+			return "testimonial:" + ctx.IP()
+		},
+		LimitReached: func(ctx *fiber.Ctx) error {
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(
+				models.JSONResponseModel{
+					Message: i18n.Message(ctx, "Too many testimonials, please try again later"),
+				},
+			)
+		},
+	})
 }
 
 // @Tags         Testimonial
 // @Summary      Get Testimonial
+// @Description  Only approved testimonials are listed, together with the reply of the SEA Catering team.
 // @Accept       json
 // @Produce      json
 // @Param        limit query int false "Limit"
 // @Param        page query int false "Page"
+// @Param        sort query string false "Sort (newest, highest, lowest)"
+// @Param        rating query int false "Star rating (1-5)"
+// @Param        min_rating query number false "Minimum rating"
+// @Param        plan_id query string false "Plan ID"
 // @Router       /testimonials [get]
-// @Success      200  {object}  models.JSONResponseModel
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetPublicTestimonialsResponse}
 // @Failure      400  {object}  models.JSONResponseModel
 func (h *TestimonialHandler) GetTestimonials(ctx *fiber.Ctx) error {
 	var req dto.GetTestimonialQuery
@@ -39,13 +76,13 @@ func (h *TestimonialHandler) GetTestimonials(ctx *fiber.Ctx) error {
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
-				Message: "Invalid Request",
-				Errors:  err.Error(),
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
 			},
 		)
 	}
 
-	err = h.validator.Validate(req)
+	err = h.validator.ValidateLocale(req, i18n.Locale(ctx))
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(
 			err.(*validator.ValidationError),
@@ -56,15 +93,15 @@ func (h *TestimonialHandler) GetTestimonials(ctx *fiber.Ctx) error {
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
-				Message: "Invalid Request",
-				Errors:  err.Error(),
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
 			},
 		)
 	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(
 		models.JSONResponseModel{
-			Message: "Testimonials Found",
+			Message: i18n.Message(ctx, "Testimonials Found"),
 			Data:    response,
 		},
 	)
@@ -72,15 +109,67 @@ func (h *TestimonialHandler) GetTestimonials(ctx *fiber.Ctx) error {
 
 // @Tags         Testimonial
 // @Summary      Create a new Testimonial
+// @Description  New testimonials are pending until a moderator approves them. Guests have to send a token from the challenge endpoint, each token can be used once. Submissions are limited per IP address, and spam is flagged for moderators. Signed in customers can review a plan once, their review shows their account name and a verified badge when they have or had a subscription.
 // @Accept       json
 // @Produce      json
 // @Param        request  body  dto.TestimonialRequest  true  "Request body"
 // @Router       /testimonials [post]
+// @Security     BearerAuth
 // @Success      200  {object}  models.JSONResponseModel
 // @Failure      400  {object}  models.JSONResponseModel
+// @Failure      429  {object}  models.JSONResponseModel
 func (h *TestimonialHandler) CreateTestimonial(ctx *fiber.Ctx) error {
 	var req dto.TestimonialRequest
 	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	err = h.validator.ValidateLocale(req, i18n.Locale(ctx))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	err = h.testimonialUsacase.CreateTestimonial(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(
+		models.JSONResponseModel{
+			Message: i18n.Message(ctx, "Testimonial submitted and awaiting moderation"),
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Get Testimonial Moderation Queue
+// @Description  Lists testimonials by moderation status, pending ones by default.
+// @Accept       json
+// @Produce      json
+// @Param        status query string false "Status (PENDING, APPROVED, REJECTED, HIDDEN)"
+// @Param        flagged query bool false "Only testimonials flagged by spam checks"
+// @Param        limit query int false "Limit"
+// @Param        page query int false "Page"
+// @Router       /testimonials/moderation [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) GetModerationQueue(ctx *fiber.Ctx) error {
+	var req dto.GetModerationQueueQuery
+	err := ctx.QueryParser(&req)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
@@ -97,7 +186,38 @@ func (h *TestimonialHandler) CreateTestimonial(ctx *fiber.Ctx) error {
 		)
 	}
 
-	err = h.testimonialUsacase.CreateTestimonial(req)
+	response, err := h.testimonialUsacase.GetModerationQueue(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to get testimonials",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Testimonials Found",
+			Data:    response,
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Moderate a Testimonial
+// @Description  Approve, reject or hide a testimonial. Only approved testimonials are public.
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Testimonial ID"
+// @Param        request  body  dto.ModerateTestimonialRequest  true  "Request body"
+// @Router       /testimonials/{id}/status [patch]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) ModerateTestimonial(ctx *fiber.Ctx) error {
+	var req dto.ModerateTestimonialRequest
+	err := ctx.BodyParser(&req)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(
 			models.JSONResponseModel{
@@ -107,9 +227,257 @@ func (h *TestimonialHandler) CreateTestimonial(ctx *fiber.Ctx) error {
 		)
 	}
 
-	return ctx.Status(fiber.StatusCreated).JSON(
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	err = h.testimonialUsacase.ModerateTestimonial(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to moderate testimonial",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Testimonial moderated successfully",
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Delete a Testimonial
+// @Description  Customers can delete their own review, moderators can delete any testimonial.
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Testimonial ID"
+// @Router       /testimonials/{id} [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) DeleteTestimonial(ctx *fiber.Ctx) error {
+	err := h.testimonialUsacase.DeleteTestimonial(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete testimonial",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Testimonial deleted successfully",
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Get My Testimonials
+// @Description  Lists the reviews of the signed in user in every moderation status.
+// @Accept       json
+// @Produce      json
+// @Router       /testimonials/mine [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) GetMyTestimonials(ctx *fiber.Ctx) error {
+	testimonials, err := h.testimonialUsacase.GetMyTestimonials(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Failed to get testimonials"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: i18n.Message(ctx, "Testimonials Found"),
+			Data:    testimonials,
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Update My Testimonial
+// @Description  Edited reviews are hidden again until a moderator approves them.
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Testimonial ID"
+// @Param        request  body  dto.UpdateTestimonialRequest  true  "Request body"
+// @Router       /testimonials/{id} [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) UpdateTestimonial(ctx *fiber.Ctx) error {
+	var req dto.UpdateTestimonialRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	err = h.validator.ValidateLocale(req, i18n.Locale(ctx))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	err = h.testimonialUsacase.UpdateTestimonial(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Failed to update testimonial"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: i18n.Message(ctx, "Testimonial updated and awaiting moderation"),
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Get Testimonial Rating Stats
+// @Description  Average rating and star histogram of approved testimonials, overall and per plan.
+// @Accept       json
+// @Produce      json
+// @Router       /testimonials/stats [get]
+// @Success      200  {object}  models.JSONResponseModel{data=dto.TestimonialStatsResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) GetTestimonialStats(ctx *fiber.Ctx) error {
+	stats, err := h.testimonialUsacase.GetTestimonialStats(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Failed to get testimonial stats"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: i18n.Message(ctx, "Testimonial stats retrieved successfully"),
+			Data:    stats,
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Get Testimonial Challenge
+// @Description  Issues the token guests send with a new testimonial. It is bound to the client IP address and expires after 30 minutes.
+// @Accept       json
+// @Produce      json
+// @Router       /testimonials/challenge [get]
+// @Success      200  {object}  models.JSONResponseModel{data=dto.TestimonialChallengeResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) CreateChallenge(ctx *fiber.Ctx) error {
+	challenge, err := h.testimonialUsacase.CreateChallenge(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: i18n.Message(ctx, "Invalid Request"),
+				Errors:  i18n.Message(ctx, err.Error()),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: i18n.Message(ctx, "Challenge issued"),
+			Data:    challenge,
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Reply to a Testimonial
+// @Description  Creates or replaces the public reply to a testimonial. With notify set, reviewers who wrote it from their account get an email.
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Testimonial ID"
+// @Param        request  body  dto.ReplyTestimonialRequest  true  "Request body"
+// @Router       /testimonials/{id}/reply [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) ReplyTestimonial(ctx *fiber.Ctx) error {
+	var req dto.ReplyTestimonialRequest
+	err := ctx.BodyParser(&req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	err = h.validator.Validate(req)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	err = h.testimonialUsacase.ReplyTestimonial(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to reply to testimonial",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Reply saved successfully",
+		},
+	)
+}
+
+// @Tags         Testimonial
+// @Summary      Delete a Testimonial Reply
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Testimonial ID"
+// @Router       /testimonials/{id}/reply [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *TestimonialHandler) DeleteReply(ctx *fiber.Ctx) error {
+	err := h.testimonialUsacase.DeleteReply(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete reply",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
 		models.JSONResponseModel{
-			Message: "Testimonials Created Successfully",
+			Message: "Reply deleted successfully",
 		},
 	)
 }
@@ -1,17 +1,35 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TestimonialPostgreSQLItf interface {
+	WithTx(tx *gorm.DB) TestimonialPostgreSQLItf
+
 	CreateTestimonial(req entity.Testimonial) error
-	GetTestimonials(testimonialQuery dto.GetTestimonialQuery) ([]entity.Testimonial, error)
+	GetTestimonials(testimonialQuery dto.GetTestimonialQuery) ([]entity.Testimonial, int64, error)
+	GetRatingCounts() ([]dto.TestimonialRatingCount, error)
+	HasDuplicateMessage(message string, since time.Time) (bool, error)
+	UseChallenge(id uuid.UUID, expiresAt time.Time) error
 	GetSpecificTestimonial(req entity.Testimonial) (entity.Testimonial, error)
 	DeleteTestimonial(req entity.Testimonial) error
+	UpdateTestimonialStatus(id uuid.UUID, status string, moderatedBy uuid.UUID) error
+	UpdateTestimonial(id uuid.UUID, fields map[string]any) error
+	GetUserTestimonials(userId uuid.UUID) ([]entity.Testimonial, error)
+	GetUserPlanTestimonial(userId uuid.UUID, planId *string) (entity.Testimonial, error)
+	HasSubscribed(userId uuid.UUID, planId *string) (bool, error)
+	DeleteUserTestimonials(userId uuid.UUID) error
+	SetReply(id uuid.UUID, message string, author entity.User) error
+	DeleteReply(id uuid.UUID) error
 }
 
 type TestimonialPostgreSQL struct {
@@ -22,6 +40,11 @@ func NewTestimonialPostgreSQL(db *gorm.DB) TestimonialPostgreSQLItf {
 	return &TestimonialPostgreSQL{db}
 }
 
+// WithTx runs the repository on a transaction of another repository
+func (r *TestimonialPostgreSQL) WithTx(tx *gorm.DB) TestimonialPostgreSQLItf {
+	return &TestimonialPostgreSQL{tx}
+}
+
 func (r *TestimonialPostgreSQL) CreateTestimonial(req entity.Testimonial) error {
 	req.ID = uuid.New()
 	err := r.db.Create(&req).Error
@@ -32,19 +55,70 @@ func (r *TestimonialPostgreSQL) CreateTestimonial(req entity.Testimonial) error
 	return nil
 }
 
-func (r *TestimonialPostgreSQL) GetTestimonials(testimonialQuery dto.GetTestimonialQuery) ([]entity.Testimonial, error) {
+func (r *TestimonialPostgreSQL) GetTestimonials(testimonialQuery dto.GetTestimonialQuery) ([]entity.Testimonial, int64, error) {
 	var testimonials []entity.Testimonial
+	var total int64
 	query := r.db.Model(&entity.Testimonial{})
 
+	if testimonialQuery.Status != "" {
+		query = query.Where("status = ?", testimonialQuery.Status)
+	}
+
+	if testimonialQuery.Flagged {
+		query = query.Where("flag_reason <> ''")
+	}
+
+	if testimonialQuery.Rating != 0 {
+		query = query.Where("ROUND(rating::numeric) = ?", testimonialQuery.Rating)
+	}
+
+	if testimonialQuery.MinRating != 0 {
+		query = query.Where("rating >= ?", testimonialQuery.MinRating)
+	}
+
+	if testimonialQuery.PlanId != "" {
+		query = query.Where("plan_id = ?", testimonialQuery.PlanId)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	switch testimonialQuery.Sort {
+	case "highest":
+		query = query.Order("rating DESC, created_at DESC")
+	case "lowest":
+		query = query.Order("rating ASC, created_at DESC")
+	default:
+		query = query.Order("created_at DESC")
+	}
+
 	query = query.Limit(testimonialQuery.Limit)
 	query = query.Offset((testimonialQuery.Page - 1) * testimonialQuery.Limit)
 
 	err := query.Find(&testimonials).Error
 	if err != nil {
-		return []entity.Testimonial{}, err
+		return []entity.Testimonial{}, 0, err
 	}
 
-	return testimonials, nil
+	return testimonials, total, nil
+}
+
+// GetRatingCounts groups the approved testimonials by plan and star rating
+func (r *TestimonialPostgreSQL) GetRatingCounts() ([]dto.TestimonialRatingCount, error) {
+	var counts []dto.TestimonialRatingCount
+	err := r.db.Table("testimonials t").
+		Select("t.plan_id, COALESCE(p.name, '') AS plan_name, ROUND(t.rating::numeric)::int AS stars, COUNT(*) AS count, SUM(t.rating) AS rating_sum").
+		Joins("LEFT JOIN plans p ON p.id = t.plan_id").
+		Where("t.status = ?", constant.TestimonialStatusApproved).
+		Group("t.plan_id, p.name, stars").
+		Order("p.name, stars").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
 }
 
 func (r *TestimonialPostgreSQL) GetSpecificTestimonial(req entity.Testimonial) (entity.Testimonial, error) {
@@ -58,6 +132,56 @@ func (r *TestimonialPostgreSQL) GetSpecificTestimonial(req entity.Testimonial) (
 	return testimonial, nil
 }
 
+func (r *TestimonialPostgreSQL) UpdateTestimonialStatus(id uuid.UUID, status string, moderatedBy uuid.UUID) error {
+	fields := map[string]any{
+		"status":       status,
+		"moderated_by": moderatedBy,
+		"moderated_at": time.Now(),
+	}
+
+	// Approving a flagged testimonial dismisses the flag
+	if status == constant.TestimonialStatusApproved {
+		fields["flag_reason"] = ""
+	}
+
+	return r.db.Model(&entity.Testimonial{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// HasDuplicateMessage reports whether the same message, ignoring case and surrounding spaces, was posted since the given time
+func (r *TestimonialPostgreSQL) HasDuplicateMessage(message string, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.Testimonial{}).
+		Where("LOWER(TRIM(message)) = LOWER(TRIM(?)) AND created_at >= ?", message, since).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// UseChallenge records a challenge token as used and fails when it was used before.
+// Tokens that expired can not be replayed anyway, so their records are dropped.
+func (r *TestimonialPostgreSQL) UseChallenge(id uuid.UUID, expiresAt time.Time) error {
+	if err := r.db.Where("expires_at < ?", time.Now()).Delete(&entity.TestimonialChallenge{}).Error; err != nil {
+		return err
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.TestimonialChallenge{
+		ID:        id,
+		ExpiresAt: expiresAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("challenge token has already been used")
+	}
+
+	return nil
+}
+
 func (r *TestimonialPostgreSQL) DeleteTestimonial(req entity.Testimonial) error {
 	err := r.db.Delete(&req).Error
 
@@ -67,3 +191,73 @@ func (r *TestimonialPostgreSQL) DeleteTestimonial(req entity.Testimonial) error
 
 	return nil
 }
+
+func (r *TestimonialPostgreSQL) UpdateTestimonial(id uuid.UUID, fields map[string]any) error {
+	return r.db.Model(&entity.Testimonial{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (r *TestimonialPostgreSQL) GetUserTestimonials(userId uuid.UUID) ([]entity.Testimonial, error) {
+	var testimonials []entity.Testimonial
+	err := r.db.Where("user_id = ?", userId).Order("created_at DESC").Find(&testimonials).Error
+	if err != nil {
+		return []entity.Testimonial{}, err
+	}
+
+	return testimonials, nil
+}
+
+// GetUserPlanTestimonial returns the review of a user for a plan, or their general review when planId is nil
+func (r *TestimonialPostgreSQL) GetUserPlanTestimonial(userId uuid.UUID, planId *string) (entity.Testimonial, error) {
+	var testimonial entity.Testimonial
+	query := r.db.Where("user_id = ?", userId)
+	if planId == nil {
+		query = query.Where("plan_id IS NULL")
+	} else {
+		query = query.Where("plan_id = ?", *planId)
+	}
+
+	err := query.First(&testimonial).Error
+	if err != nil {
+		return entity.Testimonial{}, err
+	}
+
+	return testimonial, nil
+}
+
+// HasSubscribed reports whether the user has or had a subscription to the plan, or to any plan when planId is nil
+func (r *TestimonialPostgreSQL) HasSubscribed(userId uuid.UUID, planId *string) (bool, error) {
+	var count int64
+	query := r.db.Model(&entity.Subscription{}).Where("user_id = ?", userId)
+	if planId != nil {
+		query = query.Where("plan_id = ?", *planId)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *TestimonialPostgreSQL) DeleteUserTestimonials(userId uuid.UUID) error {
+	return r.db.Where("user_id = ?", userId).Delete(&entity.Testimonial{}).Error
+}
+
+func (r *TestimonialPostgreSQL) SetReply(id uuid.UUID, message string, author entity.User) error {
+	return r.db.Model(&entity.Testimonial{}).Where("id = ?", id).Updates(map[string]any{
+		"reply_message":   message,
+		"reply_author":    author.Name,
+		"reply_author_id": author.ID,
+		"replied_at":      time.Now(),
+	}).Error
+}
+
+func (r *TestimonialPostgreSQL) DeleteReply(id uuid.UUID) error {
+	return r.db.Model(&entity.Testimonial{}).Where("id = ?", id).Updates(map[string]any{
+		"reply_message":   "",
+		"reply_author":    "",
+		"reply_author_id": nil,
+		"replied_at":      nil,
+	}).Error
+}
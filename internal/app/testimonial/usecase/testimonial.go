@@ -1,26 +1,393 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/config"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	permissionUsecase "github.com/jevvonn/sea-catering-be/internal/app/permission/usecase"
+	plansRepo "github.com/jevvonn/sea-catering-be/internal/app/plans/repository"
 	"github.com/jevvonn/sea-catering-be/internal/app/testimonial/repository"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"github.com/jevvonn/sea-catering-be/internal/infra/jwt"
+	"github.com/jevvonn/sea-catering-be/internal/infra/mailer"
+	"gorm.io/gorm"
 )
 
 type TestimonialUsecaseItf interface {
-	GetTestimonials(ctx *fiber.Ctx, query dto.GetTestimonialQuery) (dto.GetTestimonialsResponse, error)
-	CreateTestimonial(req dto.TestimonialRequest) error
+	GetTestimonials(ctx *fiber.Ctx, query dto.GetTestimonialQuery) (dto.GetPublicTestimonialsResponse, error)
+	CreateChallenge(ctx *fiber.Ctx) (dto.TestimonialChallengeResponse, error)
+	CreateTestimonial(ctx *fiber.Ctx, req dto.TestimonialRequest) error
+	GetMyTestimonials(ctx *fiber.Ctx) ([]entity.Testimonial, error)
+	UpdateTestimonial(ctx *fiber.Ctx, req dto.UpdateTestimonialRequest) error
+	GetTestimonialStats(ctx *fiber.Ctx) (dto.TestimonialStatsResponse, error)
+	GetModerationQueue(ctx *fiber.Ctx, query dto.GetModerationQueueQuery) (dto.GetTestimonialsResponse, error)
+	ModerateTestimonial(ctx *fiber.Ctx, req dto.ModerateTestimonialRequest) error
+	DeleteTestimonial(ctx *fiber.Ctx) error
+	ReplyTestimonial(ctx *fiber.Ctx, req dto.ReplyTestimonialRequest) error
+	DeleteReply(ctx *fiber.Ctx) error
 }
 
 type TestimonialUsecase struct {
-	testimonialRepo repository.TestimonialPostgreSQLItf
+	testimonialRepo   repository.TestimonialPostgreSQLItf
+	userRepo          userRepo.UserPostgreSQLItf
+	plansRepo         plansRepo.PlansPostgreSQLItf
+	auditRepo         auditRepo.AuditPostgreSQLItf
+	permissionUsecase permissionUsecase.PermissionUsecaseItf
+	mailer            mailer.MailerService
+
+	// bannedWordList flags testimonials containing any of its words for moderation
+	bannedWordList []string
+}
+
+func NewTestimonialUsecase(
+	testimonialRepo repository.TestimonialPostgreSQLItf,
+	userRepo userRepo.UserPostgreSQLItf,
+	plansRepo plansRepo.PlansPostgreSQLItf,
+	auditRepo auditRepo.AuditPostgreSQLItf,
+	permissionUsecase permissionUsecase.PermissionUsecaseItf,
+	mailer mailer.MailerService,
+) TestimonialUsecaseItf {
+	return &TestimonialUsecase{testimonialRepo, userRepo, plansRepo, auditRepo, permissionUsecase, mailer, config.Load().BannedWords}
 }
 
-func NewTestimonialUsecase(testimonialRepo repository.TestimonialPostgreSQLItf) TestimonialUsecaseItf {
-	return &TestimonialUsecase{testimonialRepo}
+func (u *TestimonialUsecase) GetTestimonials(ctx *fiber.Ctx, query dto.GetTestimonialQuery) (dto.GetPublicTestimonialsResponse, error) {
+	// Only approved testimonials are public
+	query.Status = constant.TestimonialStatusApproved
+
+	result, err := u.listTestimonials(query)
+	if err != nil {
+		return dto.GetPublicTestimonialsResponse{}, err
+	}
+
+	response := dto.GetPublicTestimonialsResponse{
+		Testimonials: []dto.TestimonialResponse{},
+		Total:        result.Total,
+		Page:         result.Page,
+		Limit:        result.Limit,
+	}
+	for _, testimonial := range result.Testimonials {
+		response.Testimonials = append(response.Testimonials, dto.TestimonialResponse{
+			ID:           testimonial.ID,
+			Name:         testimonial.Name,
+			Message:      testimonial.Message,
+			Rating:       testimonial.Rating,
+			PlanId:       testimonial.PlanId,
+			IsVerified:   testimonial.IsVerified,
+			ReplyMessage: testimonial.ReplyMessage,
+			ReplyAuthor:  testimonial.ReplyAuthor,
+			RepliedAt:    testimonial.RepliedAt,
+			CreatedAt:    testimonial.CreatedAt,
+			UpdatedAt:    testimonial.UpdatedAt,
+		})
+	}
+
+	return response, nil
 }
 
-func (u *TestimonialUsecase) GetTestimonials(ctx *fiber.Ctx, query dto.GetTestimonialQuery) (dto.GetTestimonialsResponse, error) {
+func (u *TestimonialUsecase) CreateTestimonial(ctx *fiber.Ctx, req dto.TestimonialRequest) error {
+	testimonial := entity.Testimonial{
+		Name:    req.Name,
+		Message: req.Message,
+		Rating:  req.Rating,
+		Status:  constant.TestimonialStatusPending,
+	}
+
+	userIdStr, ok := ctx.Locals("userId").(string)
+	if !ok {
+		if req.PlanId != "" {
+			return errors.New("sign in to review a plan")
+		}
+
+		if strings.TrimSpace(req.Name) == "" {
+			return errors.New("name is required")
+		}
+
+		challenge, err := jwt.ParseTestimonialChallenge(req.ChallengeToken, ctx.IP())
+		if err != nil {
+			return errors.New("invalid or expired challenge token")
+		}
+
+		if err := u.testimonialRepo.UseChallenge(challenge.ID, challenge.ExpiresAt); err != nil {
+			return err
+		}
+
+		reasons, err := u.spamReasons(req)
+		if err != nil {
+			return err
+		}
+
+		if req.Website != "" {
+			reasons = append(reasons, "honeypot field filled in")
+		}
+
+		if time.Since(challenge.IssuedAt) < constant.TestimonialChallengeMinAge {
+			reasons = append(reasons, "submitted too quickly")
+		}
+
+		testimonial.FlagReason = strings.Join(reasons, ", ")
+		return u.testimonialRepo.CreateTestimonial(testimonial)
+	}
+
+	user, err := u.userRepo.GetSpecificUser(entity.User{ID: uuid.MustParse(userIdStr)})
+	if err != nil {
+		return err
+	}
+
+	// Signed in reviews carry the account name so customers can not be impersonated
+	testimonial.Name = user.Name
+	testimonial.UserID = &user.ID
+
+	if req.PlanId != "" {
+		_, err := u.plansRepo.GetSpecificPlans(entity.Plans{ID: req.PlanId})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("plan not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		testimonial.PlanId = &req.PlanId
+	}
+
+	_, err = u.testimonialRepo.GetUserPlanTestimonial(user.ID, testimonial.PlanId)
+	if err == nil {
+		if testimonial.PlanId == nil {
+			return errors.New("you have already written a review, edit it instead")
+		}
+		return errors.New("you have already reviewed this plan, edit your review instead")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	testimonial.IsVerified, err = u.testimonialRepo.HasSubscribed(user.ID, testimonial.PlanId)
+	if err != nil {
+		return err
+	}
+
+	reasons, err := u.spamReasons(req)
+	if err != nil {
+		return err
+	}
+
+	testimonial.FlagReason = strings.Join(reasons, ", ")
+	return u.testimonialRepo.CreateTestimonial(testimonial)
+}
+
+func (u *TestimonialUsecase) CreateChallenge(ctx *fiber.Ctx) (dto.TestimonialChallengeResponse, error) {
+	token, expiresAt, err := jwt.CreateTestimonialChallenge(ctx.IP(), constant.TestimonialChallengeTTL)
+	if err != nil {
+		return dto.TestimonialChallengeResponse{}, err
+	}
+
+	return dto.TestimonialChallengeResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (u *TestimonialUsecase) GetMyTestimonials(ctx *fiber.Ctx) ([]entity.Testimonial, error) {
+	userId := uuid.MustParse(ctx.Locals("userId").(string))
+	return u.testimonialRepo.GetUserTestimonials(userId)
+}
+
+func (u *TestimonialUsecase) UpdateTestimonial(ctx *fiber.Ctx, req dto.UpdateTestimonialRequest) error {
+	testimonial, err := u.getTestimonial(ctx)
+	if err != nil {
+		return err
+	}
+
+	userId := uuid.MustParse(ctx.Locals("userId").(string))
+	if testimonial.UserID == nil || *testimonial.UserID != userId {
+		return errors.New("you can only edit your own review")
+	}
+
+	verified, err := u.testimonialRepo.HasSubscribed(userId, testimonial.PlanId)
+	if err != nil {
+		return err
+	}
+
+	flagReason := ""
+	if words := bannedWords(req.Message, u.bannedWordList); len(words) > 0 {
+		flagReason = "banned words: " + strings.Join(words, ", ")
+	}
+
+	// Edited reviews go through moderation again
+	return u.testimonialRepo.UpdateTestimonial(testimonial.ID, map[string]any{
+		"message":      req.Message,
+		"rating":       req.Rating,
+		"is_verified":  verified,
+		"flag_reason":  flagReason,
+		"status":       constant.TestimonialStatusPending,
+		"moderated_by": nil,
+		"moderated_at": nil,
+	})
+}
+
+func (u *TestimonialUsecase) GetTestimonialStats(ctx *fiber.Ctx) (dto.TestimonialStatsResponse, error) {
+	counts, err := u.testimonialRepo.GetRatingCounts()
+	if err != nil {
+		return dto.TestimonialStatsResponse{}, err
+	}
+
+	overall := newRatingSummary()
+	overallSum := 0.0
+	plans := []dto.PlanRatingSummary{}
+	planSums := []float64{}
+	planIndex := map[string]int{}
+
+	for _, count := range counts {
+		overall.Count += count.Count
+		overall.Histogram[count.Stars] += count.Count
+		overallSum += count.RatingSum
+
+		if count.PlanId == nil {
+			continue
+		}
+
+		index, ok := planIndex[*count.PlanId]
+		if !ok {
+			index = len(plans)
+			planIndex[*count.PlanId] = index
+			plans = append(plans, dto.PlanRatingSummary{
+				PlanId:        *count.PlanId,
+				PlanName:      count.PlanName,
+				RatingSummary: newRatingSummary(),
+			})
+			planSums = append(planSums, 0)
+		}
+
+		plans[index].Count += count.Count
+		plans[index].Histogram[count.Stars] += count.Count
+		planSums[index] += count.RatingSum
+	}
+
+	overall.Average = averageRating(overallSum, overall.Count)
+	for i := range plans {
+		plans[i].Average = averageRating(planSums[i], plans[i].Count)
+	}
+
+	return dto.TestimonialStatsResponse{
+		Overall: overall,
+		Plans:   plans,
+	}, nil
+}
+
+func (u *TestimonialUsecase) GetModerationQueue(ctx *fiber.Ctx, query dto.GetModerationQueueQuery) (dto.GetTestimonialsResponse, error) {
+	if query.Status == "" {
+		query.Status = constant.TestimonialStatusPending
+	}
+
+	return u.listTestimonials(dto.GetTestimonialQuery{
+		Limit:   query.Limit,
+		Page:    query.Page,
+		Status:  query.Status,
+		Flagged: query.Flagged,
+	})
+}
+
+func (u *TestimonialUsecase) ModerateTestimonial(ctx *fiber.Ctx, req dto.ModerateTestimonialRequest) error {
+	testimonial, err := u.getTestimonial(ctx)
+	if err != nil {
+		return err
+	}
+
+	if testimonial.Status == req.Status {
+		return errors.New("testimonial is already " + req.Status)
+	}
+
+	moderatorId := uuid.MustParse(ctx.Locals("userId").(string))
+	err = u.testimonialRepo.UpdateTestimonialStatus(testimonial.ID, req.Status, moderatorId)
+	if err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionTestimonialModerated, testimonial.ID, testimonial.Status+" -> "+req.Status)
+}
+
+func (u *TestimonialUsecase) DeleteTestimonial(ctx *fiber.Ctx) error {
+	testimonial, err := u.getTestimonial(ctx)
+	if err != nil {
+		return err
+	}
+
+	userId := uuid.MustParse(ctx.Locals("userId").(string))
+	isOwner := testimonial.UserID != nil && *testimonial.UserID == userId
+	if !isOwner {
+		role := ctx.Locals("role").(string)
+		canModerate, err := u.permissionUsecase.HasPermission(role, constant.PermissionTestimonialsModerate)
+		if err != nil {
+			return err
+		}
+
+		if !canModerate {
+			return errors.New("you can only delete your own review")
+		}
+	}
+
+	err = u.testimonialRepo.DeleteTestimonial(testimonial)
+	if err != nil {
+		return err
+	}
+
+	if isOwner {
+		return nil
+	}
+
+	return u.audit(ctx, constant.AuditActionTestimonialDeleted, testimonial.ID, "")
+}
+
+// spamReasons runs the content checks shared by guests and customers. Matches
+// are not rejected, the testimonial is flagged for moderators instead.
+func (u *TestimonialUsecase) spamReasons(req dto.TestimonialRequest) ([]string, error) {
+	reasons := []string{}
+
+	duplicate, err := u.testimonialRepo.HasDuplicateMessage(req.Message, time.Now().Add(-constant.TestimonialDuplicateWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	if duplicate {
+		reasons = append(reasons, "duplicate message")
+	}
+
+	if words := bannedWords(req.Name+" "+req.Message, u.bannedWordList); len(words) > 0 {
+		reasons = append(reasons, "banned words: "+strings.Join(words, ", "))
+	}
+
+	return reasons, nil
+}
+
+// bannedWords returns the banned words found in the text
+func bannedWords(text string, bannedWordList []string) []string {
+	found := []string{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for _, banned := range bannedWordList {
+		banned = strings.ToLower(strings.TrimSpace(banned))
+		if banned != "" && slices.Contains(words, banned) {
+			found = append(found, banned)
+		}
+	}
+
+	return found
+}
+
+func (u *TestimonialUsecase) listTestimonials(query dto.GetTestimonialQuery) (dto.GetTestimonialsResponse, error) {
 	if query.Limit <= 0 {
 		query.Limit = 10
 	}
@@ -29,30 +396,117 @@ func (u *TestimonialUsecase) GetTestimonials(ctx *fiber.Ctx, query dto.GetTestim
 		query.Page = 1
 	}
 
-	testimonials, err := u.testimonialRepo.GetTestimonials(query)
+	testimonials, total, err := u.testimonialRepo.GetTestimonials(query)
 	if err != nil {
 		return dto.GetTestimonialsResponse{}, err
 	}
 
 	return dto.GetTestimonialsResponse{
 		Testimonials: testimonials,
-		Total:        len(testimonials),
+		Total:        total,
 		Page:         query.Page,
 		Limit:        query.Limit,
 	}, nil
 }
 
-func (u *TestimonialUsecase) CreateTestimonial(req dto.TestimonialRequest) error {
-	testimonial := entity.Testimonial{
-		Name:    req.Name,
-		Message: req.Message,
-		Rating:  req.Rating,
+func (u *TestimonialUsecase) ReplyTestimonial(ctx *fiber.Ctx, req dto.ReplyTestimonialRequest) error {
+	testimonial, err := u.getTestimonial(ctx)
+	if err != nil {
+		return err
+	}
+
+	author, err := u.userRepo.GetSpecificUser(entity.User{ID: uuid.MustParse(ctx.Locals("userId").(string))})
+	if err != nil {
+		return err
+	}
+
+	err = u.testimonialRepo.SetReply(testimonial.ID, req.Message, author)
+	if err != nil {
+		return err
 	}
 
-	err := u.testimonialRepo.CreateTestimonial(testimonial)
+	if !req.Notify || testimonial.UserID == nil {
+		return nil
+	}
+
+	reviewer, err := u.userRepo.GetSpecificUser(entity.User{ID: *testimonial.UserID})
 	if err != nil {
 		return err
 	}
 
+	if reviewer.AnonymizedAt != nil {
+		return nil
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nThe SEA Catering team replied to your review:\n\n\"%s\"\n\n%s\n- %s",
+		reviewer.Name,
+		testimonial.Message,
+		req.Message,
+		author.Name,
+	)
+
+	if err := u.mailer.Send(reviewer.Email, "We replied to your review", body); err != nil {
+		return fmt.Errorf("reply saved but the reviewer could not be notified: %w", err)
+	}
+
 	return nil
 }
+
+func (u *TestimonialUsecase) DeleteReply(ctx *fiber.Ctx) error {
+	testimonial, err := u.getTestimonial(ctx)
+	if err != nil {
+		return err
+	}
+
+	if testimonial.RepliedAt == nil {
+		return errors.New("testimonial has no reply")
+	}
+
+	return u.testimonialRepo.DeleteReply(testimonial.ID)
+}
+
+func (u *TestimonialUsecase) getTestimonial(ctx *fiber.Ctx) (entity.Testimonial, error) {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return entity.Testimonial{}, errors.New("invalid testimonial ID")
+	}
+
+	testimonial, err := u.testimonialRepo.GetSpecificTestimonial(entity.Testimonial{ID: id})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.Testimonial{}, errors.New("testimonial not found")
+	}
+
+	return testimonial, err
+}
+
+func (u *TestimonialUsecase) audit(ctx *fiber.Ctx, action string, testimonialId uuid.UUID, details string) error {
+	actorId := uuid.MustParse(ctx.Locals("userId").(string))
+
+	return u.auditRepo.CreateAuditLog(entity.AuditLog{
+		ActorID:    &actorId,
+		Action:     action,
+		TargetType: "TESTIMONIAL",
+		TargetID:   testimonialId.String(),
+		IPAddress:  ctx.IP(),
+		Details:    details,
+	})
+}
+
+// newRatingSummary returns an empty summary with every star present in the histogram
+func newRatingSummary() dto.RatingSummary {
+	histogram := map[int]int64{}
+	for stars := 1; stars <= 5; stars++ {
+		histogram[stars] = 0
+	}
+
+	return dto.RatingSummary{Histogram: histogram}
+}
+
+func averageRating(sum float64, count int64) float64 {
+	if count == 0 {
+		return 0
+	}
+
+	return math.Round(sum/float64(count)*100) / 100
+}
@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	plansRepo "github.com/jevvonn/sea-catering-be/internal/app/plans/repository"
+	"github.com/jevvonn/sea-catering-be/internal/app/testimonial/repository"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type fakeTestimonialRepo struct {
+	repository.TestimonialPostgreSQLItf
+
+	testimonials []entity.Testimonial
+	duplicate    bool
+	subscribed   bool
+	query        dto.GetTestimonialQuery
+	created      []entity.Testimonial
+	updated      map[string]any
+	statuses     []string
+}
+
+func (r *fakeTestimonialRepo) GetTestimonials(query dto.GetTestimonialQuery) ([]entity.Testimonial, int64, error) {
+	r.query = query
+	return r.testimonials, int64(len(r.testimonials)), nil
+}
+
+func (r *fakeTestimonialRepo) GetSpecificTestimonial(req entity.Testimonial) (entity.Testimonial, error) {
+	for _, testimonial := range r.testimonials {
+		if testimonial.ID == req.ID {
+			return testimonial, nil
+		}
+	}
+
+	return entity.Testimonial{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeTestimonialRepo) GetUserPlanTestimonial(userId uuid.UUID, planId *string) (entity.Testimonial, error) {
+	for _, testimonial := range r.testimonials {
+		if testimonial.UserID != nil && *testimonial.UserID == userId && reflect.DeepEqual(testimonial.PlanId, planId) {
+			return testimonial, nil
+		}
+	}
+
+	return entity.Testimonial{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeTestimonialRepo) HasDuplicateMessage(message string, since time.Time) (bool, error) {
+	return r.duplicate, nil
+}
+
+func (r *fakeTestimonialRepo) HasSubscribed(userId uuid.UUID, planId *string) (bool, error) {
+	return r.subscribed, nil
+}
+
+func (r *fakeTestimonialRepo) CreateTestimonial(req entity.Testimonial) error {
+	r.created = append(r.created, req)
+	return nil
+}
+
+func (r *fakeTestimonialRepo) UpdateTestimonial(id uuid.UUID, fields map[string]any) error {
+	r.updated = fields
+	return nil
+}
+
+func (r *fakeTestimonialRepo) UpdateTestimonialStatus(id uuid.UUID, status string, moderatedBy uuid.UUID) error {
+	r.statuses = append(r.statuses, status)
+	return nil
+}
+
+type fakeUserRepo struct {
+	userRepo.UserPostgreSQLItf
+
+	users []entity.User
+}
+
+func (r *fakeUserRepo) GetSpecificUser(req entity.User) (entity.User, error) {
+	for _, user := range r.users {
+		if user.ID == req.ID {
+			return user, nil
+		}
+	}
+
+	return entity.User{}, gorm.ErrRecordNotFound
+}
+
+type fakePlansRepo struct {
+	plansRepo.PlansPostgreSQLItf
+}
+
+func (r *fakePlansRepo) GetSpecificPlans(req entity.Plans) (entity.Plans, error) {
+	return entity.Plans{ID: req.ID}, nil
+}
+
+type fakeAuditRepo struct {
+	auditRepo.AuditPostgreSQLItf
+
+	logs []entity.AuditLog
+}
+
+func (r *fakeAuditRepo) CreateAuditLog(log entity.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func newTestTestimonialUsecase(testimonials *fakeTestimonialRepo, users ...entity.User) (*TestimonialUsecase, *fakeAuditRepo) {
+	audit := &fakeAuditRepo{}
+	return &TestimonialUsecase{
+		testimonialRepo: testimonials,
+		userRepo:        &fakeUserRepo{users: users},
+		plansRepo:       &fakePlansRepo{},
+		auditRepo:       audit,
+		bannedWordList:  []string{"spam", " Casino "},
+	}, audit
+}
+
+// call runs fn inside a request to /:id so the usecase can read the route
+// params and the locals set by the auth middleware
+func call(t *testing.T, id string, userId string, fn func(ctx *fiber.Ctx) error) error {
+	t.Helper()
+
+	var err error
+	app := fiber.New()
+	app.Get("/:id", func(ctx *fiber.Ctx) error {
+		if userId != "" {
+			ctx.Locals("userId", userId)
+		}
+		err = fn(ctx)
+		return nil
+	})
+
+	if _, testErr := app.Test(httptest.NewRequest(fiber.MethodGet, "/"+id, nil)); testErr != nil {
+		t.Fatal(testErr)
+	}
+
+	return err
+}
+
+func TestBannedWords(t *testing.T) {
+	got := bannedWords("Great SPAM! Casinos nearby, casino-grade food", []string{"spam", " Casino ", ""})
+	want := []string{"spam", "casino"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("banned words = %v, want %v", got, want)
+	}
+
+	if got := bannedWords("spammy casinos", []string{"spam", "casino"}); len(got) != 0 {
+		t.Fatalf("banned words = %v, want none for partial matches", got)
+	}
+}
+
+func TestCreateTestimonialFlagsSignedInReview(t *testing.T) {
+	user := entity.User{ID: uuid.New(), Name: "Jane Doe"}
+	repo := &fakeTestimonialRepo{duplicate: true, subscribed: true}
+	u, _ := newTestTestimonialUsecase(repo, user)
+
+	err := call(t, "x", user.ID.String(), func(ctx *fiber.Ctx) error {
+		return u.CreateTestimonial(ctx, dto.TestimonialRequest{Name: "Admin", PlanId: "diet", Message: "Buy SPAM now", Rating: 5})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.created) != 1 {
+		t.Fatalf("created %d testimonials, want 1", len(repo.created))
+	}
+
+	created := repo.created[0]
+	if created.Status != constant.TestimonialStatusPending {
+		t.Fatalf("status = %s, want %s", created.Status, constant.TestimonialStatusPending)
+	}
+	if created.Name != user.Name {
+		t.Fatalf("name = %q, want the account name %q", created.Name, user.Name)
+	}
+	if !created.IsVerified {
+		t.Fatal("review of a subscribed plan is not verified")
+	}
+	if created.FlagReason != "duplicate message, banned words: spam" {
+		t.Fatalf("flag reason = %q", created.FlagReason)
+	}
+}
+
+func TestCreateTestimonialRejectsSecondReview(t *testing.T) {
+	user := entity.User{ID: uuid.New(), Name: "Jane Doe"}
+	planId := "diet"
+	repo := &fakeTestimonialRepo{testimonials: []entity.Testimonial{{ID: uuid.New(), UserID: &user.ID, PlanId: &planId}}}
+	u, _ := newTestTestimonialUsecase(repo, user)
+
+	err := call(t, "x", user.ID.String(), func(ctx *fiber.Ctx) error {
+		return u.CreateTestimonial(ctx, dto.TestimonialRequest{PlanId: planId, Message: "Again", Rating: 4})
+	})
+	if err == nil || !strings.Contains(err.Error(), "already reviewed this plan") {
+		t.Fatalf("err = %v, want an already reviewed error", err)
+	}
+
+	// A review of another plan is still allowed
+	err = call(t, "x", user.ID.String(), func(ctx *fiber.Ctx) error {
+		return u.CreateTestimonial(ctx, dto.TestimonialRequest{PlanId: "protein", Message: "Other plan", Rating: 4})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateTestimonialGuestRules(t *testing.T) {
+	tests := []struct {
+		name string
+		req  dto.TestimonialRequest
+		want string
+	}{
+		{"plan review", dto.TestimonialRequest{Name: "Guest", PlanId: "diet", Message: "Nice", Rating: 5}, "sign in to review a plan"},
+		{"no name", dto.TestimonialRequest{Name: "  ", Message: "Nice", Rating: 5}, "name is required"},
+		{"no challenge", dto.TestimonialRequest{Name: "Guest", Message: "Nice", Rating: 5}, "invalid or expired challenge token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeTestimonialRepo{}
+			u, _ := newTestTestimonialUsecase(repo)
+
+			err := call(t, "x", "", func(ctx *fiber.Ctx) error {
+				return u.CreateTestimonial(ctx, tt.req)
+			})
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+			if len(repo.created) != 0 {
+				t.Fatal("rejected testimonial was stored")
+			}
+		})
+	}
+}
+
+func TestUpdateTestimonialReturnsToModeration(t *testing.T) {
+	owner := uuid.New()
+	moderator := uuid.New()
+	testimonial := entity.Testimonial{ID: uuid.New(), UserID: &owner, Status: constant.TestimonialStatusApproved, ModeratedBy: &moderator}
+	repo := &fakeTestimonialRepo{testimonials: []entity.Testimonial{testimonial}}
+	u, _ := newTestTestimonialUsecase(repo)
+
+	err := call(t, testimonial.ID.String(), uuid.NewString(), func(ctx *fiber.Ctx) error {
+		return u.UpdateTestimonial(ctx, dto.UpdateTestimonialRequest{Message: "Edited", Rating: 4})
+	})
+	if err == nil || repo.updated != nil {
+		t.Fatalf("err = %v, want someone else's review to be rejected", err)
+	}
+
+	err = call(t, testimonial.ID.String(), owner.String(), func(ctx *fiber.Ctx) error {
+		return u.UpdateTestimonial(ctx, dto.UpdateTestimonialRequest{Message: "Now with casino tips", Rating: 4})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if repo.updated["status"] != constant.TestimonialStatusPending {
+		t.Fatalf("status = %v, want %s", repo.updated["status"], constant.TestimonialStatusPending)
+	}
+	if repo.updated["moderated_by"] != nil || repo.updated["moderated_at"] != nil {
+		t.Fatalf("moderation was not cleared: %v", repo.updated)
+	}
+	if repo.updated["flag_reason"] != "banned words: casino" {
+		t.Fatalf("flag reason = %v", repo.updated["flag_reason"])
+	}
+}
+
+func TestModerateTestimonial(t *testing.T) {
+	testimonial := entity.Testimonial{ID: uuid.New(), Status: constant.TestimonialStatusPending}
+	repo := &fakeTestimonialRepo{testimonials: []entity.Testimonial{testimonial}}
+	u, audit := newTestTestimonialUsecase(repo)
+	moderator := uuid.NewString()
+
+	err := call(t, testimonial.ID.String(), moderator, func(ctx *fiber.Ctx) error {
+		return u.ModerateTestimonial(ctx, dto.ModerateTestimonialRequest{Status: constant.TestimonialStatusPending})
+	})
+	if err == nil || len(repo.statuses) != 0 {
+		t.Fatalf("err = %v, want moving to the same status to be rejected", err)
+	}
+
+	err = call(t, testimonial.ID.String(), moderator, func(ctx *fiber.Ctx) error {
+		return u.ModerateTestimonial(ctx, dto.ModerateTestimonialRequest{Status: constant.TestimonialStatusApproved})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(repo.statuses, []string{constant.TestimonialStatusApproved}) {
+		t.Fatalf("statuses = %v", repo.statuses)
+	}
+	if len(audit.logs) != 1 || audit.logs[0].Details != "PENDING -> APPROVED" {
+		t.Fatalf("audit logs = %+v", audit.logs)
+	}
+}
+
+func TestGetTestimonialsHidesModeration(t *testing.T) {
+	moderator := uuid.New()
+	now := time.Now()
+	repo := &fakeTestimonialRepo{testimonials: []entity.Testimonial{{
+		ID:          uuid.New(),
+		Name:        "Jane Doe",
+		Message:     "Great",
+		Rating:      5,
+		Status:      constant.TestimonialStatusApproved,
+		FlagReason:  "duplicate message",
+		ModeratedBy: &moderator,
+		ModeratedAt: &now,
+	}}}
+	u, _ := newTestTestimonialUsecase(repo)
+
+	var response dto.GetPublicTestimonialsResponse
+	err := call(t, "x", "", func(ctx *fiber.Ctx) error {
+		var err error
+		response, err = u.GetTestimonials(ctx, dto.GetTestimonialQuery{})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if repo.query.Status != constant.TestimonialStatusApproved {
+		t.Fatalf("status filter = %q, want %s", repo.query.Status, constant.TestimonialStatusApproved)
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []string{"moderated_by", "moderated_at", "flag_reason", "status"} {
+		if strings.Contains(string(body), `"`+field+`"`) {
+			t.Fatalf("public response exposes %s: %s", field, body)
+		}
+	}
+}
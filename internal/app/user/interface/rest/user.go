@@ -1,7 +1,569 @@
 package rest
 
-type UserHandler struct{}
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/app/user/usecase"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
+	"github.com/jevvonn/sea-catering-be/internal/models"
+)
 
-func NewUserHandler() {
-	// UserHandler := UserHandler{}
+type UserHandler struct {
+	userUsecase usecase.UserUsecaseItf
+	validator   validator.ValidationService
+}
+
+func NewUserHandler(
+	router fiber.Router,
+	mw *middleware.Middleware,
+	userUsecase usecase.UserUsecaseItf,
+	validator validator.ValidationService,
+) {
+	handler := UserHandler{userUsecase, validator}
+
+	router.Get("/users/me", mw.Authenticated, handler.GetProfile)
+	router.Patch("/users/me", mw.Authenticated, handler.UpdateProfile)
+	router.Put("/users/me/password", mw.Authenticated, handler.ChangePassword)
+	router.Post("/users/me/email", mw.Authenticated, handler.ChangeEmail)
+	router.Post("/users/me/email/verify", mw.Authenticated, handler.VerifyEmail)
+	router.Get("/users/me/export", mw.Authenticated, handler.ExportData)
+	router.Delete("/users/me", mw.Authenticated, handler.DeleteAccount)
+
+	router.Get("/users", mw.Authenticated, mw.RequirePermission(constant.PermissionUsersRead), handler.GetUsers)
+	router.Get("/users/:id", mw.Authenticated, mw.RequirePermission(constant.PermissionUsersRead), handler.GetUser)
+	router.Put("/users/:id/role", mw.Authenticated, mw.RequirePermission(constant.PermissionUsersWrite), handler.UpdateUserRole)
+	router.Post("/users/:id/deactivate", mw.Authenticated, mw.RequirePermission(constant.PermissionUsersWrite), handler.DeactivateUser)
+	router.Post("/users/:id/reactivate", mw.Authenticated, mw.RequirePermission(constant.PermissionUsersWrite), handler.ReactivateUser)
+	router.Post("/users/:id/reset-password", mw.Authenticated, mw.RequirePermission(constant.PermissionUsersWrite), handler.ResetUserPassword)
+	router.Delete("/users/:id", mw.Authenticated, mw.RequirePermission(constant.PermissionUsersWrite), handler.DeleteUser)
+}
+
+// @Tags         User
+// @Summary      Get All Users
+// @Accept       json
+// @Produce      json
+// @Param        search query string false "Search by name or email"
+// @Param        role query string false "Role, e.g USER"
+// @Param        status query string false "ACTIVE or DEACTIVATED"
+// @Param        limit query int false "Limit"
+// @Param        page query int false "Page"
+// @Router       /users [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetUsersResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) GetUsers(ctx *fiber.Ctx) error {
+	var req dto.GetUsersQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	users, err := h.userUsecase.GetUsers(ctx, req)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve users",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Users retrieved successfully",
+			Data:    users,
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Get Specific User with Their Subscriptions
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID"
+// @Router       /users/{userId} [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.GetUserDetailResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) GetUser(ctx *fiber.Ctx) error {
+	user, err := h.userUsecase.GetUser(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve user",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "User retrieved successfully",
+			Data:    user,
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Change User Role
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID"
+// @Param        request body dto.UpdateUserRoleRequest true "Request body"
+// @Router       /users/{userId}/role [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) UpdateUserRole(ctx *fiber.Ctx) error {
+	var req dto.UpdateUserRoleRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.userUsecase.UpdateUserRole(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update user role",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "User role updated successfully",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Deactivate User
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID"
+// @Router       /users/{userId}/deactivate [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) DeactivateUser(ctx *fiber.Ctx) error {
+	if err := h.userUsecase.DeactivateUser(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to deactivate user",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "User deactivated successfully",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Reactivate User
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID"
+// @Router       /users/{userId}/reactivate [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) ReactivateUser(ctx *fiber.Ctx) error {
+	if err := h.userUsecase.ReactivateUser(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to reactivate user",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "User reactivated successfully",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Reset User Password
+// @Description  Replaces the user's password with a generated temporary password.
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID"
+// @Router       /users/{userId}/reset-password [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.ResetUserPasswordResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) ResetUserPassword(ctx *fiber.Ctx) error {
+	res, err := h.userUsecase.ResetUserPassword(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to reset user password",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "User password reset successfully",
+			Data:    res,
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Get My Profile
+// @Accept       json
+// @Produce      json
+// @Router       /users/me [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.ProfileResponse}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) GetProfile(ctx *fiber.Ctx) error {
+	profile, err := h.userUsecase.GetProfile(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to retrieve profile",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Profile retrieved successfully",
+			Data:    profile,
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Update My Profile
+// @Description  Contact name and phone number are used when a new subscription is created without them.
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateProfileRequest true "Request body"
+// @Router       /users/me [patch]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) UpdateProfile(ctx *fiber.Ctx) error {
+	var req dto.UpdateProfileRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.userUsecase.UpdateProfile(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to update profile",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Profile updated successfully",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Change My Password
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ChangePasswordRequest true "Request body"
+// @Router       /users/me/password [put]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) ChangePassword(ctx *fiber.Ctx) error {
+	var req dto.ChangePasswordRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.userUsecase.ChangePassword(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to change password",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Password changed successfully",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Change My Email
+// @Description  Sends a verification code to the new address, the email only changes once it is verified.
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.ChangeEmailRequest true "Request body"
+// @Router       /users/me/email [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) ChangeEmail(ctx *fiber.Ctx) error {
+	var req dto.ChangeEmailRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.userUsecase.ChangeEmail(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to change email",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Verification code sent to the new email",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Verify My New Email
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.VerifyEmailRequest true "Request body"
+// @Router       /users/me/email/verify [post]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) VerifyEmail(ctx *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.userUsecase.VerifyEmail(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to verify email",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Email verified successfully",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Export My Personal Data
+// @Description  Returns the profile and subscriptions as JSON, or as CSV files in a zip archive with format=zip.
+// @Produce      json
+// @Produce      application/zip
+// @Param        format query string false "json (default) or zip"
+// @Router       /users/me/export [get]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel{data=dto.UserDataExport}
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) ExportData(ctx *fiber.Ctx) error {
+	var req dto.ExportUserDataQuery
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid Request",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if req.Format == "zip" {
+		archive, err := h.userUsecase.ExportDataArchive(ctx)
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(
+				models.JSONResponseModel{
+					Message: "Failed to export data",
+					Errors:  err.Error(),
+				},
+			)
+		}
+
+		ctx.Set(fiber.HeaderContentType, "application/zip")
+		ctx.Attachment("sea-catering-data.zip")
+		return ctx.Status(fiber.StatusOK).Send(archive)
+	}
+
+	data, err := h.userUsecase.ExportData(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to export data",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Data exported successfully",
+			Data:    data,
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Delete My Account
+// @Description  Anonymizes the account and the personal data on its subscriptions, active subscriptions are cancelled.
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.DeleteAccountRequest true "Request body"
+// @Router       /users/me [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) DeleteAccount(ctx *fiber.Ctx) error {
+	var req dto.DeleteAccountRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			models.JSONResponseModel{
+				Message: "Invalid request body",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(
+			err.(*validator.ValidationError),
+		)
+	}
+
+	if err := h.userUsecase.DeleteAccount(ctx, req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete account",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "Account deleted successfully",
+		},
+	)
+}
+
+// @Tags         User
+// @Summary      Delete User
+// @Description  Anonymizes the account and the personal data on its subscriptions, active subscriptions are cancelled.
+// @Accept       json
+// @Produce      json
+// @Param        userId path string true "User ID"
+// @Router       /users/{userId} [delete]
+// @Security     BearerAuth
+// @Success      200  {object}  models.JSONResponseModel
+// @Failure      400  {object}  models.JSONResponseModel
+func (h *UserHandler) DeleteUser(ctx *fiber.Ctx) error {
+	if err := h.userUsecase.DeleteUser(ctx); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(
+			models.JSONResponseModel{
+				Message: "Failed to delete user",
+				Errors:  err.Error(),
+			},
+		)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(
+		models.JSONResponseModel{
+			Message: "User deleted successfully",
+		},
+	)
 }
@@ -1,13 +1,28 @@
 package repository
 
 import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
 	"gorm.io/gorm"
 )
 
 type UserPostgreSQLItf interface {
+	Transaction(fn func(tx *gorm.DB) error) error
+	WithTx(tx *gorm.DB) UserPostgreSQLItf
+
 	GetSpecificUser(user entity.User) (entity.User, error)
+	GetUserByEmail(email string) (entity.User, error)
 	CreateUser(user entity.User) error
+	GetUsers(query dto.GetUsersQuery) ([]entity.User, int64, error)
+	UpdateUser(user entity.User) error
+	SetUserActive(userId uuid.UUID, active bool) error
+	SetPendingEmail(userId uuid.UUID, email string, token string, expiresAt *time.Time) error
+	ConfirmPendingEmail(userId uuid.UUID) error
+	AnonymizeUser(userId uuid.UUID, password string) error
 }
 
 type UserPostgreSQL struct {
@@ -18,12 +33,150 @@ func NewUserPostgreSQL(db *gorm.DB) UserPostgreSQLItf {
 	return &UserPostgreSQL{db}
 }
 
+// WithTx runs the repository on a transaction of another repository
+func (r *UserPostgreSQL) WithTx(tx *gorm.DB) UserPostgreSQLItf {
+	return &UserPostgreSQL{tx}
+}
+
+// Transaction runs fn in a transaction other repositories can join with WithTx
+func (r *UserPostgreSQL) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
 func (r *UserPostgreSQL) GetSpecificUser(user entity.User) (entity.User, error) {
 	var result entity.User
 	err := r.db.First(&result, &user).Error
 	return result, err
 }
 
+// GetUserByEmail ignores the case of the email, older accounts were stored as typed
+func (r *UserPostgreSQL) GetUserByEmail(email string) (entity.User, error) {
+	var result entity.User
+	err := r.db.First(&result, "LOWER(email) = LOWER(?)", email).Error
+	return result, err
+}
+
 func (r *UserPostgreSQL) CreateUser(user entity.User) error {
 	return r.db.Create(&user).Error
 }
+
+func (r *UserPostgreSQL) GetUsers(query dto.GetUsersQuery) ([]entity.User, int64, error) {
+	var users []entity.User
+	var total int64
+
+	db := r.db.Model(&entity.User{})
+	if query.Search != "" {
+		search := "%" + query.Search + "%"
+		db = db.Where("name ILIKE ? OR email ILIKE ?", search, search)
+	}
+
+	if query.Role != "" {
+		db = db.Where("role = ?", query.Role)
+	}
+
+	if query.Status != "" {
+		db = db.Where("is_active = ?", query.Status == constant.UserStatusActive)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("created_at DESC").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *UserPostgreSQL) UpdateUser(user entity.User) error {
+	if user.ID == uuid.Nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	data := map[string]any{}
+
+	if user.Name != "" {
+		data["name"] = user.Name
+	}
+
+	if user.Email != "" {
+		data["email"] = user.Email
+	}
+
+	if user.Password != "" {
+		data["password"] = user.Password
+	}
+
+	if user.Role != "" {
+		data["role"] = user.Role
+	}
+
+	if user.ContactName != "" {
+		data["contact_name"] = user.ContactName
+	}
+
+	if user.PhoneNumber != "" {
+		data["phone_number"] = user.PhoneNumber
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return r.db.Model(entity.User{}).Where("id = ?", user.ID).Updates(&data).Error
+}
+
+func (r *UserPostgreSQL) SetUserActive(userId uuid.UUID, active bool) error {
+	var deactivatedAt *time.Time
+	if !active {
+		now := time.Now()
+		deactivatedAt = &now
+	}
+
+	return r.db.Model(entity.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"is_active":      active,
+		"deactivated_at": deactivatedAt,
+	}).Error
+}
+
+func (r *UserPostgreSQL) SetPendingEmail(userId uuid.UUID, email string, token string, expiresAt *time.Time) error {
+	return r.db.Model(entity.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"pending_email":                 email,
+		"email_verification_token":      token,
+		"email_verification_expires_at": expiresAt,
+	}).Error
+}
+
+func (r *UserPostgreSQL) ConfirmPendingEmail(userId uuid.UUID) error {
+	return r.db.Model(entity.User{}).
+		Where("id = ? AND pending_email <> ''", userId).
+		Updates(map[string]any{
+			"email":                         gorm.Expr("pending_email"),
+			"pending_email":                 "",
+			"email_verification_token":      "",
+			"email_verification_expires_at": nil,
+		}).Error
+}
+
+func (r *UserPostgreSQL) AnonymizeUser(userId uuid.UUID, password string) error {
+	now := time.Now()
+
+	return r.db.Model(entity.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"name":                          constant.DeletedUserName,
+		"email":                         "deleted-" + userId.String() + "@" + constant.DeletedUserEmailDomain,
+		"password":                      password,
+		"contact_name":                  "",
+		"phone_number":                  "",
+		"pending_email":                 "",
+		"email_verification_token":      "",
+		"email_verification_expires_at": nil,
+		"is_active":                     false,
+		"deactivated_at":                now,
+		"anonymized_at":                 now,
+	}).Error
+}
@@ -1,9 +1,648 @@
 package usecase
 
-type UserUsecaseItf interface {}
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
-type UserUsecase struct {}
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/config"
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	orgRepo "github.com/jevvonn/sea-catering-be/internal/app/organization/repository"
+	permissionUsecase "github.com/jevvonn/sea-catering-be/internal/app/permission/usecase"
+	subRepo "github.com/jevvonn/sea-catering-be/internal/app/subscription/repository"
+	testimonialRepo "github.com/jevvonn/sea-catering-be/internal/app/testimonial/repository"
+	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/domain/dto"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	"github.com/jevvonn/sea-catering-be/internal/infra/mailer"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
+	"gorm.io/gorm"
+)
 
-func NewUserUsecase() UserUsecaseItf {
-    return &UserUsecase{}
+type UserUsecaseItf interface {
+	GetUsers(ctx *fiber.Ctx, query dto.GetUsersQuery) (dto.GetUsersResponse, error)
+	GetUser(ctx *fiber.Ctx) (dto.GetUserDetailResponse, error)
+	UpdateUserRole(ctx *fiber.Ctx, req dto.UpdateUserRoleRequest) error
+	DeactivateUser(ctx *fiber.Ctx) error
+	ReactivateUser(ctx *fiber.Ctx) error
+	ResetUserPassword(ctx *fiber.Ctx) (dto.ResetUserPasswordResponse, error)
+	GetAuthenticatedUser(userId string) (entity.User, error)
+
+	GetProfile(ctx *fiber.Ctx) (dto.ProfileResponse, error)
+	UpdateProfile(ctx *fiber.Ctx, req dto.UpdateProfileRequest) error
+	ChangePassword(ctx *fiber.Ctx, req dto.ChangePasswordRequest) error
+	ChangeEmail(ctx *fiber.Ctx, req dto.ChangeEmailRequest) error
+	VerifyEmail(ctx *fiber.Ctx, req dto.VerifyEmailRequest) error
+	ExportData(ctx *fiber.Ctx) (dto.UserDataExport, error)
+	ExportDataArchive(ctx *fiber.Ctx) ([]byte, error)
+	DeleteAccount(ctx *fiber.Ctx, req dto.DeleteAccountRequest) error
+	DeleteUser(ctx *fiber.Ctx) error
+}
+
+type UserUsecase struct {
+	userRepo          userRepo.UserPostgreSQLItf
+	subRepo           subRepo.SubscriptionPostgreSQLItf
+	testimonialRepo   testimonialRepo.TestimonialPostgreSQLItf
+	orgRepo           orgRepo.OrganizationPostgreSQLItf
+	auditRepo         auditRepo.AuditPostgreSQLItf
+	permissionUsecase permissionUsecase.PermissionUsecaseItf
+	mailer            mailer.MailerService
+}
+
+func NewUserUsecase(
+	userRepo userRepo.UserPostgreSQLItf,
+	subRepo subRepo.SubscriptionPostgreSQLItf,
+	testimonialRepo testimonialRepo.TestimonialPostgreSQLItf,
+	orgRepo orgRepo.OrganizationPostgreSQLItf,
+	auditRepo auditRepo.AuditPostgreSQLItf,
+	permissionUsecase permissionUsecase.PermissionUsecaseItf,
+	mailer mailer.MailerService,
+) UserUsecaseItf {
+	return &UserUsecase{userRepo, subRepo, testimonialRepo, orgRepo, auditRepo, permissionUsecase, mailer}
+}
+
+func (u *UserUsecase) GetUsers(ctx *fiber.Ctx, query dto.GetUsersQuery) (dto.GetUsersResponse, error) {
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+
+	users, total, err := u.userRepo.GetUsers(query)
+	if err != nil {
+		return dto.GetUsersResponse{}, err
+	}
+
+	response := []dto.UserResponse{}
+	for _, user := range users {
+		response = append(response, toUserResponse(user))
+	}
+
+	return dto.GetUsersResponse{
+		Users: response,
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}, nil
+}
+
+func (u *UserUsecase) GetUser(ctx *fiber.Ctx) (dto.GetUserDetailResponse, error) {
+	user, err := u.getParamUser(ctx)
+	if err != nil {
+		return dto.GetUserDetailResponse{}, err
+	}
+
+	subscriptions, err := u.subRepo.GetSubscriptions(entity.Subscription{
+		UserID: user.ID,
+	})
+	if err != nil {
+		return dto.GetUserDetailResponse{}, err
+	}
+
+	userSubscriptions := []dto.UserSubscriptionResponse{}
+	for _, sub := range subscriptions {
+		userSubscriptions = append(userSubscriptions, dto.UserSubscriptionResponse{
+			ID:         sub.ID,
+			PlanId:     sub.PlanId,
+			PlanName:   sub.Plans.Name,
+			Status:     sub.Status,
+			TotalPrice: sub.TotalPrice,
+			CreatedAt:  sub.CreatedAt,
+		})
+	}
+
+	return dto.GetUserDetailResponse{
+		UserResponse:  toUserResponse(user),
+		Subscriptions: userSubscriptions,
+	}, nil
+}
+
+func (u *UserUsecase) UpdateUserRole(ctx *fiber.Ctx, req dto.UpdateUserRoleRequest) error {
+	user, err := u.getParamUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	role := strings.ToUpper(req.Role)
+	exists, err := u.permissionUsecase.RoleExists(role)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errors.New("role not found")
+	}
+
+	if user.ID.String() == ctx.Locals("userId").(string) {
+		return errors.New("you cannot change your own role")
+	}
+
+	if err := u.userRepo.UpdateUser(entity.User{ID: user.ID, Role: role}); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionUserRoleChanged, user.ID, user.Role+" -> "+role)
+}
+
+func (u *UserUsecase) DeactivateUser(ctx *fiber.Ctx) error {
+	user, err := u.getParamUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if user.ID.String() == ctx.Locals("userId").(string) {
+		return errors.New("you cannot deactivate your own account")
+	}
+
+	if !user.IsActive {
+		return errors.New("user is already deactivated")
+	}
+
+	if err := u.userRepo.SetUserActive(user.ID, false); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionUserDeactivated, user.ID, "")
+}
+
+func (u *UserUsecase) ReactivateUser(ctx *fiber.Ctx) error {
+	user, err := u.getParamUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if user.IsActive {
+		return errors.New("user is already active")
+	}
+
+	if err := u.userRepo.SetUserActive(user.ID, true); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionUserReactivated, user.ID, "")
+}
+
+func (u *UserUsecase) ResetUserPassword(ctx *fiber.Ctx) (dto.ResetUserPasswordResponse, error) {
+	user, err := u.getParamUser(ctx)
+	if err != nil {
+		return dto.ResetUserPasswordResponse{}, err
+	}
+
+	temporaryPassword, err := utils.GenerateRandomString(constant.TemporaryPasswordLength)
+	if err != nil {
+		return dto.ResetUserPasswordResponse{}, err
+	}
+
+	hashedPassword, err := utils.HashPassword(temporaryPassword)
+	if err != nil {
+		return dto.ResetUserPasswordResponse{}, err
+	}
+
+	if err := u.userRepo.UpdateUser(entity.User{ID: user.ID, Password: hashedPassword}); err != nil {
+		return dto.ResetUserPasswordResponse{}, err
+	}
+
+	if err := u.audit(ctx, constant.AuditActionUserPasswordReset, user.ID, ""); err != nil {
+		return dto.ResetUserPasswordResponse{}, err
+	}
+
+	return dto.ResetUserPasswordResponse{
+		TemporaryPassword: temporaryPassword,
+	}, nil
+}
+
+// GetAuthenticatedUser loads the user a token was issued to, so role and status changes apply
+// before the token expires
+func (u *UserUsecase) GetAuthenticatedUser(userId string) (entity.User, error) {
+	// A token for an id that can not exist has no user either
+	uuidUser, err := uuid.Parse(userId)
+	if err != nil {
+		return entity.User{}, gorm.ErrRecordNotFound
+	}
+
+	return u.userRepo.GetSpecificUser(entity.User{ID: uuidUser})
+}
+
+func (u *UserUsecase) GetProfile(ctx *fiber.Ctx) (dto.ProfileResponse, error) {
+	user, err := u.getSessionUser(ctx)
+	if err != nil {
+		return dto.ProfileResponse{}, err
+	}
+
+	return dto.ProfileResponse{
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Role:         user.Role,
+		ContactName:  user.ContactName,
+		PhoneNumber:  user.PhoneNumber,
+		PendingEmail: user.PendingEmail,
+		CreatedAt:    user.CreatedAt,
+	}, nil
+}
+
+func (u *UserUsecase) UpdateProfile(ctx *fiber.Ctx, req dto.UpdateProfileRequest) error {
+	user, err := u.getSessionUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	return u.userRepo.UpdateUser(entity.User{
+		ID:          user.ID,
+		Name:        strings.TrimSpace(req.Name),
+		ContactName: strings.TrimSpace(req.ContactName),
+		PhoneNumber: strings.TrimSpace(req.PhoneNumber),
+	})
+}
+
+func (u *UserUsecase) ChangePassword(ctx *fiber.Ctx, req dto.ChangePasswordRequest) error {
+	user, err := u.getSessionUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !utils.VerifyPassword(req.CurrentPassword, user.Password) {
+		return errors.New("current password is incorrect")
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userRepo.UpdateUser(entity.User{ID: user.ID, Password: hashedPassword}); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionUserPasswordChanged, user.ID, "")
+}
+
+func (u *UserUsecase) ChangeEmail(ctx *fiber.Ctx, req dto.ChangeEmailRequest) error {
+	user, err := u.getSessionUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !utils.VerifyPassword(req.Password, user.Password) {
+		return errors.New("password is incorrect")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == strings.ToLower(user.Email) {
+		return errors.New("new email is the same as the current email")
+	}
+
+	existing, err := u.userRepo.GetUserByEmail(email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if existing.ID != uuid.Nil {
+		return errors.New("email already exists")
+	}
+
+	token, err := utils.GenerateRandomString(constant.EmailVerificationTokenLength)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(constant.EmailVerificationTTL)
+	if err := u.userRepo.SetPendingEmail(user.ID, email, utils.HashToken(token), &expiresAt); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nUse this code to confirm your new SEA Catering email address: %s\n\nThe code expires in %s.",
+		user.Name,
+		token,
+		constant.EmailVerificationTTL,
+	)
+
+	if frontendURL := config.Load().FrontendURL; frontendURL != "" {
+		body += fmt.Sprintf("\n\nOr open this link: %s/verify-email?token=%s", strings.TrimRight(frontendURL, "/"), token)
+	}
+
+	return u.mailer.Send(email, "Confirm your new email address", body)
+}
+
+func (u *UserUsecase) VerifyEmail(ctx *fiber.Ctx, req dto.VerifyEmailRequest) error {
+	user, err := u.getSessionUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if user.PendingEmail == "" || user.EmailVerificationToken != utils.HashToken(req.Token) {
+		return errors.New("invalid verification token")
+	}
+
+	if user.EmailVerificationExpiresAt == nil || user.EmailVerificationExpiresAt.Before(time.Now()) {
+		return errors.New("verification token has expired")
+	}
+
+	// The address may have been registered by someone else in the meantime
+	existing, err := u.userRepo.GetUserByEmail(user.PendingEmail)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if existing.ID != uuid.Nil {
+		return errors.New("email already exists")
+	}
+
+	if err := u.userRepo.ConfirmPendingEmail(user.ID); err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionUserEmailChanged, user.ID, user.Email+" -> "+user.PendingEmail)
+}
+
+func (u *UserUsecase) ExportData(ctx *fiber.Ctx) (dto.UserDataExport, error) {
+	profile, err := u.GetProfile(ctx)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	subscriptions, err := u.subRepo.GetSubscriptions(entity.Subscription{
+		UserID: profile.ID,
+	})
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	exportSubscriptions := []dto.ExportSubscription{}
+	for _, sub := range subscriptions {
+		allergens := []string{}
+		for _, allergen := range sub.Allergens {
+			allergens = append(allergens, allergen.Name)
+		}
+
+		exportSubscriptions = append(exportSubscriptions, dto.ExportSubscription{
+			ID:             sub.ID,
+			PlanId:         sub.PlanId,
+			PlanName:       sub.Plans.Name,
+			Name:           sub.Name,
+			PhoneNumber:    sub.PhoneNumber,
+			Mealtypes:      strings.Split(sub.Mealtypes, ","),
+			DeliveryDays:   strings.Split(sub.DeliveryDays, ","),
+			Allergens:      allergens,
+			AllergyNote:    sub.AllergyNote,
+			TotalPrice:     sub.TotalPrice,
+			Status:         sub.Status,
+			PauseStartDate: sub.PauseStartDate,
+			PauseEndDate:   sub.PauseEndDate,
+			CreatedAt:      sub.CreatedAt,
+			UpdatedAt:      sub.UpdatedAt,
+		})
+	}
+
+	testimonials, err := u.testimonialRepo.GetUserTestimonials(profile.ID)
+	if err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	exportTestimonials := []dto.ExportTestimonial{}
+	for _, testimonial := range testimonials {
+		planId := ""
+		if testimonial.PlanId != nil {
+			planId = *testimonial.PlanId
+		}
+
+		exportTestimonials = append(exportTestimonials, dto.ExportTestimonial{
+			ID:         testimonial.ID,
+			PlanId:     planId,
+			Name:       testimonial.Name,
+			Message:    testimonial.Message,
+			Rating:     testimonial.Rating,
+			Status:     testimonial.Status,
+			IsVerified: testimonial.IsVerified,
+			CreatedAt:  testimonial.CreatedAt,
+			UpdatedAt:  testimonial.UpdatedAt,
+		})
+	}
+
+	if err := u.audit(ctx, constant.AuditActionUserDataExported, profile.ID, ""); err != nil {
+		return dto.UserDataExport{}, err
+	}
+
+	return dto.UserDataExport{
+		ExportedAt:    time.Now(),
+		Profile:       profile,
+		Subscriptions: exportSubscriptions,
+		Testimonials:  exportTestimonials,
+	}, nil
+}
+
+func (u *UserUsecase) ExportDataArchive(ctx *fiber.Ctx) ([]byte, error) {
+	data, err := u.ExportData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := data.Profile
+	profileRows := [][]string{
+		{"id", "name", "email", "role", "contact_name", "phone_number", "pending_email", "created_at"},
+		{
+			profile.ID.String(),
+			profile.Name,
+			profile.Email,
+			profile.Role,
+			profile.ContactName,
+			profile.PhoneNumber,
+			profile.PendingEmail,
+			formatExportTime(&profile.CreatedAt),
+		},
+	}
+
+	subscriptionRows := [][]string{
+		{"id", "plan_id", "plan_name", "name", "phone_number", "mealtype", "delivery_days", "allergens", "allergy_note", "total_price", "status", "pause_start_date", "pause_end_date", "created_at", "updated_at"},
+	}
+	for _, sub := range data.Subscriptions {
+		subscriptionRows = append(subscriptionRows, []string{
+			sub.ID.String(),
+			sub.PlanId,
+			sub.PlanName,
+			sub.Name,
+			sub.PhoneNumber,
+			strings.Join(sub.Mealtypes, ","),
+			strings.Join(sub.DeliveryDays, ","),
+			strings.Join(sub.Allergens, ","),
+			sub.AllergyNote,
+			strconv.FormatFloat(sub.TotalPrice, 'f', 2, 64),
+			sub.Status,
+			formatExportTime(sub.PauseStartDate),
+			formatExportTime(sub.PauseEndDate),
+			formatExportTime(&sub.CreatedAt),
+			formatExportTime(&sub.UpdatedAt),
+		})
+	}
+
+	testimonialRows := [][]string{
+		{"id", "plan_id", "name", "message", "rating", "status", "is_verified", "created_at", "updated_at"},
+	}
+	for _, testimonial := range data.Testimonials {
+		testimonialRows = append(testimonialRows, []string{
+			testimonial.ID.String(),
+			testimonial.PlanId,
+			testimonial.Name,
+			testimonial.Message,
+			strconv.FormatFloat(testimonial.Rating, 'f', 1, 64),
+			testimonial.Status,
+			strconv.FormatBool(testimonial.IsVerified),
+			formatExportTime(&testimonial.CreatedAt),
+			formatExportTime(&testimonial.UpdatedAt),
+		})
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	files := map[string][][]string{
+		"profile.csv":       profileRows,
+		"subscriptions.csv": subscriptionRows,
+		"testimonials.csv":  testimonialRows,
+	}
+	for _, name := range []string{"profile.csv", "subscriptions.csv", "testimonials.csv"} {
+		file, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := csv.NewWriter(file).WriteAll(files[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (u *UserUsecase) DeleteAccount(ctx *fiber.Ctx, req dto.DeleteAccountRequest) error {
+	user, err := u.getSessionUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !utils.VerifyPassword(req.Password, user.Password) {
+		return errors.New("password is incorrect")
+	}
+
+	return u.anonymizeUser(ctx, user)
+}
+
+func (u *UserUsecase) DeleteUser(ctx *fiber.Ctx) error {
+	user, err := u.getParamUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if user.ID.String() == ctx.Locals("userId").(string) {
+		return errors.New("use account deletion to delete your own account")
+	}
+
+	return u.anonymizeUser(ctx, user)
+}
+
+func (u *UserUsecase) anonymizeUser(ctx *fiber.Ctx, user entity.User) error {
+	if user.AnonymizedAt != nil {
+		return errors.New("user is already deleted")
+	}
+
+	// Nobody knows this password, so the account can never be logged into again
+	randomPassword, err := utils.GenerateRandomString(constant.EmailVerificationTokenLength)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return err
+	}
+
+	// A half anonymized account would keep personal data while looking deleted
+	err = u.userRepo.Transaction(func(tx *gorm.DB) error {
+		if err := u.subRepo.WithTx(tx).AnonymizeUserSubscriptions(user.ID, constant.DeletedUserName); err != nil {
+			return err
+		}
+
+		// Reviews are personal opinions published under the user's name, so they go with the account
+		if err := u.testimonialRepo.WithTx(tx).DeleteUserTestimonials(user.ID); err != nil {
+			return err
+		}
+
+		if err := u.orgRepo.WithTx(tx).RemoveUserMembership(user.ID); err != nil {
+			return err
+		}
+
+		return u.userRepo.WithTx(tx).AnonymizeUser(user.ID, hashedPassword)
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.audit(ctx, constant.AuditActionUserDeleted, user.ID, "")
+}
+
+func (u *UserUsecase) getSessionUser(ctx *fiber.Ctx) (entity.User, error) {
+	userId, err := uuid.Parse(ctx.Locals("userId").(string))
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return u.userRepo.GetSpecificUser(entity.User{ID: userId})
+}
+
+func (u *UserUsecase) getParamUser(ctx *fiber.Ctx) (entity.User, error) {
+	userId, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return entity.User{}, errors.New("invalid user ID format")
+	}
+
+	return u.userRepo.GetSpecificUser(entity.User{ID: userId})
+}
+
+func (u *UserUsecase) audit(ctx *fiber.Ctx, action string, targetId uuid.UUID, details string) error {
+	actorId := uuid.MustParse(ctx.Locals("userId").(string))
+
+	return u.auditRepo.CreateAuditLog(entity.AuditLog{
+		ActorID:    &actorId,
+		Action:     action,
+		TargetType: "USER",
+		TargetID:   targetId.String(),
+		IPAddress:  ctx.IP(),
+		Details:    details,
+	})
+}
+
+func toUserResponse(user entity.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		IsActive:      user.IsActive,
+		DeactivatedAt: user.DeactivatedAt,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
 }
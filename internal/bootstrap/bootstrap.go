@@ -1,30 +1,57 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	cors "github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/jevvonn/sea-catering-be/config"
 
+	auditRepo "github.com/jevvonn/sea-catering-be/internal/app/audit/repository"
+	authRepo "github.com/jevvonn/sea-catering-be/internal/app/auth/repository"
+	menuRepo "github.com/jevvonn/sea-catering-be/internal/app/menu/repository"
+	orgRepo "github.com/jevvonn/sea-catering-be/internal/app/organization/repository"
+	permissionRepo "github.com/jevvonn/sea-catering-be/internal/app/permission/repository"
 	plansRepo "github.com/jevvonn/sea-catering-be/internal/app/plans/repository"
+	reportRepo "github.com/jevvonn/sea-catering-be/internal/app/report/repository"
 	subsRepo "github.com/jevvonn/sea-catering-be/internal/app/subscription/repository"
 	testimonialRepo "github.com/jevvonn/sea-catering-be/internal/app/testimonial/repository"
 	userRepo "github.com/jevvonn/sea-catering-be/internal/app/user/repository"
 
+	auditUsecase "github.com/jevvonn/sea-catering-be/internal/app/audit/usecase"
 	authUsecase "github.com/jevvonn/sea-catering-be/internal/app/auth/usecase"
+	menuUsecase "github.com/jevvonn/sea-catering-be/internal/app/menu/usecase"
+	orgUsecase "github.com/jevvonn/sea-catering-be/internal/app/organization/usecase"
+	permissionUsecase "github.com/jevvonn/sea-catering-be/internal/app/permission/usecase"
 	plansUsecase "github.com/jevvonn/sea-catering-be/internal/app/plans/usecase"
+	reportUsecase "github.com/jevvonn/sea-catering-be/internal/app/report/usecase"
 	subsUsecase "github.com/jevvonn/sea-catering-be/internal/app/subscription/usecase"
 	testimonialUsecase "github.com/jevvonn/sea-catering-be/internal/app/testimonial/usecase"
+	userUsecase "github.com/jevvonn/sea-catering-be/internal/app/user/usecase"
 
+	auditHandler "github.com/jevvonn/sea-catering-be/internal/app/audit/interface/rest"
 	authHandler "github.com/jevvonn/sea-catering-be/internal/app/auth/interface/rest"
+	menuHandler "github.com/jevvonn/sea-catering-be/internal/app/menu/interface/rest"
+	orgHandler "github.com/jevvonn/sea-catering-be/internal/app/organization/interface/rest"
+	permissionHandler "github.com/jevvonn/sea-catering-be/internal/app/permission/interface/rest"
 	plansHandler "github.com/jevvonn/sea-catering-be/internal/app/plans/interface/rest"
+	reportHandler "github.com/jevvonn/sea-catering-be/internal/app/report/interface/rest"
 	subsHandler "github.com/jevvonn/sea-catering-be/internal/app/subscription/interface/rest"
 	testimonialHandler "github.com/jevvonn/sea-catering-be/internal/app/testimonial/interface/rest"
+	userHandler "github.com/jevvonn/sea-catering-be/internal/app/user/interface/rest"
 
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/infra/mailer"
 	"github.com/jevvonn/sea-catering-be/internal/infra/postgresql"
+	"github.com/jevvonn/sea-catering-be/internal/infra/storage"
 	"github.com/jevvonn/sea-catering-be/internal/infra/validator"
+	"github.com/jevvonn/sea-catering-be/internal/middleware"
 
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 
@@ -32,14 +59,28 @@ import (
 	_ "github.com/jevvonn/sea-catering-be/docs"
 )
 
-const idleTimeout = 5 * time.Second
+const (
+	idleTimeout = 5 * time.Second
+
+	// scheduledCancellationInterval is how often subscriptions cancelled at the end of
+	// their billing period and ended retention discounts are checked
+	scheduledCancellationInterval = 15 * time.Minute
+)
 
 func Start() error {
+	conf := config.New()
+
 	app := fiber.New(fiber.Config{
 		IdleTimeout: idleTimeout,
+
+		// Without trusted proxies the proxy header is ignored, so clients can not spoof their IP
+		ProxyHeader:             conf.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          conf.TrustedProxies,
 	})
 
 	app.Use(cors.New())
+	app.Use(middleware.Locale)
 
 	app.Use(limiter.New(limiter.Config{
 		Max:               100,
@@ -52,15 +93,14 @@ func Start() error {
 		},
 	}))
 
-	conf := config.New()
-
 	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=Asia/Jakarta",
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=%s",
 		conf.DbHost,
 		conf.DbPort,
 		conf.DbUser,
 		conf.DbPassword,
 		conf.DbName,
+		constant.Timezone,
 	)
 
 	db, err := postgresql.New(dsn)
@@ -69,6 +109,8 @@ func Start() error {
 	}
 
 	validator := validator.NewValidator()
+	mailer := mailer.NewMailer()
+	storage := storage.NewLocalStorage(conf.StorageDir, conf.StoragePublicURL)
 
 	// For migrating the database by command
 	CommandHandler(db)
@@ -81,28 +123,62 @@ func Start() error {
 	})
 
 	app.Get("/docs/*", swagger.HandlerDefault)
+	app.Static(conf.StoragePublicURL, conf.StorageDir)
 
 	apiRouter := app.Group("/api")
 
 	userRepo := userRepo.NewUserPostgreSQL(db)
+	authRepo := authRepo.NewAuthPostgreSQL(db)
+	auditRepo := auditRepo.NewAuditPostgreSQL(db)
+	permissionRepo := permissionRepo.NewPermissionPostgreSQL(db)
 	testimonialRepo := testimonialRepo.NewTestimonialPostgreSQL(db)
 	plansRepo := plansRepo.NewPlansPostgreSQL(db)
 	subsRepo := subsRepo.NewSubscriptionPostgreSQL(db)
-
-	authUsecase := authUsecase.NewAuthUsecase(userRepo)
-	testimonialUsecase := testimonialUsecase.NewTestimonialUsecase(testimonialRepo)
-	plansUsecase := plansUsecase.NewPlansUsecase(plansRepo)
-	subsUsecase := subsUsecase.NewSubscriptionUsecase(subsRepo, plansRepo)
-
-	authHandler.NewAuthHandler(apiRouter, authUsecase, validator)
-	testimonialHandler.NewTestimonialHandler(apiRouter, testimonialUsecase, validator)
-	plansHandler.NewPlansHandler(apiRouter, plansUsecase, validator)
-	subsHandler.NewSubscriptionHandler(apiRouter, subsUsecase, validator)
+	menuRepo := menuRepo.NewMenuPostgreSQL(db)
+	reportRepo := reportRepo.NewReportPostgreSQL(db)
+	orgRepo := orgRepo.NewOrganizationPostgreSQL(db)
+
+	permissionUsecase := permissionUsecase.NewPermissionUsecase(permissionRepo, auditRepo)
+	authUsecase := authUsecase.NewAuthUsecase(userRepo, authRepo, auditRepo)
+	auditUsecase := auditUsecase.NewAuditUsecase(auditRepo)
+	testimonialUsecase := testimonialUsecase.NewTestimonialUsecase(testimonialRepo, userRepo, plansRepo, auditRepo, permissionUsecase, mailer)
+	plansUsecase := plansUsecase.NewPlansUsecase(plansRepo, auditRepo, storage)
+	subsUsecase := subsUsecase.NewSubscriptionUsecase(subsRepo, plansRepo, userRepo, menuRepo, orgRepo, permissionUsecase)
+	menuUsecase := menuUsecase.NewMenuUsecase(menuRepo, plansRepo)
+	reportUsecase := reportUsecase.NewReportUsecase(reportRepo)
+	orgUsecase := orgUsecase.NewOrganizationUsecase(orgRepo, userRepo, subsUsecase, permissionUsecase, auditRepo)
+	userUsecase := userUsecase.NewUserUsecase(userRepo, subsRepo, testimonialRepo, orgRepo, auditRepo, permissionUsecase, mailer)
+
+	// The scheduled jobs and the server stop on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go subsUsecase.RunScheduledCancellations(ctx, scheduledCancellationInterval)
+
+	mw := middleware.New(userUsecase, permissionUsecase)
+
+	authHandler.NewAuthHandler(apiRouter, mw, authUsecase, validator)
+	auditHandler.NewAuditHandler(apiRouter, mw, auditUsecase, validator)
+	permissionHandler.NewPermissionHandler(apiRouter, mw, permissionUsecase, validator)
+	testimonialHandler.NewTestimonialHandler(apiRouter, mw, testimonialUsecase, validator)
+	plansHandler.NewPlansHandler(apiRouter, mw, plansUsecase, validator)
+	subsHandler.NewSubscriptionHandler(apiRouter, mw, subsUsecase, validator)
+	userHandler.NewUserHandler(apiRouter, mw, userUsecase, validator)
+	menuHandler.NewMenuHandler(apiRouter, mw, menuUsecase, validator)
+	reportHandler.NewReportHandler(apiRouter, mw, reportUsecase, validator)
+	orgHandler.NewOrganizationHandler(apiRouter, mw, orgUsecase, validator)
 
 	addr := fmt.Sprintf("localhost:%s", conf.AppPort)
 	if conf.AppEnv == "production" {
 		addr = fmt.Sprintf("0.0.0.0:%s", conf.AppPort)
 	}
 
+	go func() {
+		<-ctx.Done()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("[bootstrap] failed to shut down: %v\n", err)
+		}
+	}()
+
 	return app.Listen(addr)
 }
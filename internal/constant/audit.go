@@ -0,0 +1,33 @@
+package constant
+
+const (
+	AuditActionAccountLocked   = "AUTH_ACCOUNT_LOCKED"
+	AuditActionIPLocked        = "AUTH_IP_LOCKED"
+	AuditActionAccountUnlocked = "AUTH_ACCOUNT_UNLOCKED"
+	AuditActionIPUnlocked      = "AUTH_IP_UNLOCKED"
+
+	AuditActionRolePermissionsUpdated = "ROLE_PERMISSIONS_UPDATED"
+
+	AuditActionUserRoleChanged   = "USER_ROLE_CHANGED"
+	AuditActionUserDeactivated   = "USER_DEACTIVATED"
+	AuditActionUserReactivated   = "USER_REACTIVATED"
+	AuditActionUserPasswordReset = "USER_PASSWORD_RESET"
+
+	AuditActionUserPasswordChanged = "USER_PASSWORD_CHANGED"
+	AuditActionUserEmailChanged    = "USER_EMAIL_CHANGED"
+	AuditActionUserDataExported    = "USER_DATA_EXPORTED"
+	AuditActionUserDeleted         = "USER_DELETED"
+
+	AuditActionPlanCreated    = "PLAN_CREATED"
+	AuditActionPlanArchived   = "PLAN_ARCHIVED"
+	AuditActionPlanUnarchived = "PLAN_UNARCHIVED"
+
+	AuditActionTestimonialModerated = "TESTIMONIAL_MODERATED"
+	AuditActionTestimonialDeleted   = "TESTIMONIAL_DELETED"
+
+	AuditActionOrganizationMemberAdded   = "ORGANIZATION_MEMBER_ADDED"
+	AuditActionOrganizationMemberRemoved = "ORGANIZATION_MEMBER_REMOVED"
+	AuditActionOrganizationRoleChanged   = "ORGANIZATION_ROLE_CHANGED"
+	AuditActionInvoiceIssued             = "INVOICE_ISSUED"
+	AuditActionInvoicePaid               = "INVOICE_PAID"
+)
@@ -0,0 +1,21 @@
+package constant
+
+import "time"
+
+const (
+	LoginAttemptScopeEmail = "EMAIL"
+	LoginAttemptScopeIP    = "IP"
+
+	// Failed attempts before every new attempt has to wait a growing delay
+	LoginDelayThreshold = 3
+	LoginBaseDelay      = 1 * time.Second
+	LoginMaxDelay       = 30 * time.Second
+
+	// Failed attempts before the account or IP address is locked
+	LoginAccountLockThreshold = 5
+	LoginIPLockThreshold      = 20
+	LoginLockDuration         = 15 * time.Minute
+
+	// Failed attempts older than this window no longer count
+	LoginAttemptWindow = 30 * time.Minute
+)
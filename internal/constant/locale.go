@@ -0,0 +1,11 @@
+package constant
+
+const (
+	LocaleEnglish    = "en"
+	LocaleIndonesian = "id"
+
+	// DefaultLocale is the language plan content is written in and the fallback for missing translations
+	DefaultLocale = LocaleEnglish
+)
+
+var Locales = []string{LocaleEnglish, LocaleIndonesian}
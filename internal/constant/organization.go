@@ -0,0 +1,11 @@
+package constant
+
+const (
+	OrganizationRoleAdmin  = "ORG_ADMIN"
+	OrganizationRoleMember = "MEMBER"
+)
+
+const (
+	InvoiceStatusIssued = "ISSUED"
+	InvoiceStatusPaid   = "PAID"
+)
@@ -0,0 +1,60 @@
+package constant
+
+const (
+	PermissionSubscriptionsReadAny  = "subscriptions:read:any"
+	PermissionSubscriptionsWriteAny = "subscriptions:write:any"
+	PermissionPlansWrite            = "plans:write"
+	PermissionReportsRead           = "reports:read"
+	PermissionAuditRead             = "audit:read"
+	PermissionAuthUnlock            = "auth:unlock"
+	PermissionPermissionsManage     = "permissions:manage"
+	PermissionUsersRead             = "users:read"
+	PermissionUsersWrite            = "users:write"
+	PermissionMenusWrite            = "menus:write"
+	PermissionKitchenRead           = "kitchen:read"
+	PermissionTestimonialsModerate  = "testimonials:moderate"
+	PermissionTestimonialsReply     = "testimonials:reply"
+	PermissionSupportSearch         = "support:search"
+	PermissionOrganizationsManage   = "organizations:manage"
+)
+
+// Permissions is the catalogue created by the migration, with a short description of each entry
+var Permissions = map[string]string{
+	PermissionSubscriptionsReadAny:  "Read subscriptions of every user",
+	PermissionSubscriptionsWriteAny: "Update subscriptions of every user",
+	PermissionPlansWrite:            "Manage meal plans",
+	PermissionReportsRead:           "Read business reports",
+	PermissionAuditRead:             "Read audit logs",
+	PermissionAuthUnlock:            "Unlock locked accounts and IP addresses",
+	PermissionPermissionsManage:     "Manage role permissions",
+	PermissionUsersRead:             "Read user accounts",
+	PermissionUsersWrite:            "Change roles, deactivate accounts and reset passwords",
+	PermissionMenusWrite:            "Manage dishes and weekly menus",
+	PermissionKitchenRead:           "Read kitchen production reports",
+	PermissionTestimonialsModerate:  "Approve, reject, hide and delete testimonials",
+	PermissionTestimonialsReply:     "Reply to testimonials",
+	PermissionSupportSearch:         "Search subscriptions by customer name, email or phone number",
+	PermissionOrganizationsManage:   "Manage every organization and mark their invoices as paid",
+}
+
+// DefaultRolePermissions is granted when a permission is first created
+var DefaultRolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionSubscriptionsReadAny,
+		PermissionSubscriptionsWriteAny,
+		PermissionPlansWrite,
+		PermissionReportsRead,
+		PermissionAuditRead,
+		PermissionAuthUnlock,
+		PermissionPermissionsManage,
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionMenusWrite,
+		PermissionKitchenRead,
+		PermissionTestimonialsModerate,
+		PermissionTestimonialsReply,
+		PermissionSupportSearch,
+		PermissionOrganizationsManage,
+	},
+	RoleUser: {},
+}
@@ -0,0 +1,18 @@
+package constant
+
+const (
+	PlanTagHalal      = "halal"
+	PlanTagVegetarian = "vegetarian"
+	PlanTagVegan      = "vegan"
+	PlanTagGlutenFree = "gluten-free"
+	PlanTagDairyFree  = "dairy-free"
+
+	PlanImageMaxSize = 2 * 1024 * 1024
+)
+
+// PlanImageExtensions maps the accepted image content types to their file extension
+var PlanImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
@@ -0,0 +1,7 @@
+package constant
+
+// Timezone is the timezone of the database session and of exported reports
+const Timezone = "Asia/Jakarta"
+
+// ExportBatchSize is how many rows exports load from the database at once
+const ExportBatchSize = 500
@@ -4,3 +4,6 @@ const (
 	RoleUser  = "USER"
 	RoleAdmin = "ADMIN"
 )
+
+// Roles always exist, other roles exist once they are assigned a permission
+var Roles = []string{RoleUser, RoleAdmin}
@@ -1,8 +1,70 @@
 package constant
 
+import "time"
+
 const (
 	SubscriptionStatusActive    = "ACTIVE"
 	SubscriptionStatusCancelled = "CANCELLED"
 
 	SubscriptionTAX = 4.3
 )
+
+// Subscription events. MRR_CHANGED records a new monthly value of an active
+// subscription, such as a retention discount starting or ending.
+const (
+	SubscriptionEventCreated     = "CREATED"
+	SubscriptionEventCancelled   = "CANCELLED"
+	SubscriptionEventReactivated = "REACTIVATED"
+	SubscriptionEventMRRChanged  = "MRR_CHANGED"
+)
+
+// Reasons a customer can give when cancelling. UNSPECIFIED is recorded for
+// cancellations made without the cancellation flow.
+const (
+	CancellationReasonTooExpensive   = "TOO_EXPENSIVE"
+	CancellationReasonNotUsingEnough = "NOT_USING_ENOUGH"
+	CancellationReasonFoodQuality    = "FOOD_QUALITY"
+	CancellationReasonDietaryNeeds   = "DIETARY_NEEDS"
+	CancellationReasonDelivery       = "DELIVERY_ISSUES"
+	CancellationReasonMoving         = "MOVING"
+	CancellationReasonTemporaryBreak = "TEMPORARY_BREAK"
+	CancellationReasonOther          = "OTHER"
+	CancellationReasonUnspecified    = "UNSPECIFIED"
+)
+
+// Outcomes of a cancellation request. A scheduled cancellation becomes CANCELLED
+// at the end of the billing period, or REVOKED when the customer changes their mind.
+const (
+	CancellationOutcomeRetained  = "RETAINED"
+	CancellationOutcomeScheduled = "SCHEDULED"
+	CancellationOutcomeCancelled = "CANCELLED"
+	CancellationOutcomeRevoked   = "REVOKED"
+)
+
+const (
+	RetentionOfferDiscount = "DISCOUNT"
+	RetentionOfferPause    = "PAUSE"
+)
+
+// RetentionOffers is the offer made for each cancellation reason, reasons without
+// an offer cancel straight away. A subscription gets at most one accepted offer.
+var RetentionOffers = map[string]string{
+	CancellationReasonTooExpensive:   RetentionOfferDiscount,
+	CancellationReasonNotUsingEnough: RetentionOfferPause,
+	CancellationReasonMoving:         RetentionOfferPause,
+	CancellationReasonTemporaryBreak: RetentionOfferPause,
+}
+
+const (
+	RetentionDiscountPercent = 20
+	RetentionDiscountMonths  = 3
+	RetentionPauseWeeks      = 4
+)
+
+var (
+	DeliveryDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	Mealtypes    = []string{"Breakfast", "Lunch", "Dinner"}
+)
+
+// MealSelectionCutoff is how long before the start of a delivery date its dishes can no longer be picked
+const MealSelectionCutoff = 48 * time.Hour
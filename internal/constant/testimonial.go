@@ -0,0 +1,23 @@
+package constant
+
+import "time"
+
+const (
+	TestimonialStatusPending  = "PENDING"
+	TestimonialStatusApproved = "APPROVED"
+	TestimonialStatusRejected = "REJECTED"
+	TestimonialStatusHidden   = "HIDDEN"
+)
+
+const (
+	// Submissions per IP address within TestimonialSubmitWindow
+	TestimonialSubmitLimit  = 3
+	TestimonialSubmitWindow = 10 * time.Minute
+
+	TestimonialChallengeTTL = 30 * time.Minute
+	// Forms sent back faster than a person can type are most likely bots
+	TestimonialChallengeMinAge = 3 * time.Second
+
+	// Identical messages posted within this window are flagged as duplicates
+	TestimonialDuplicateWindow = 30 * 24 * time.Hour
+)
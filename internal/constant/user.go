@@ -0,0 +1,16 @@
+package constant
+
+import "time"
+
+const (
+	UserStatusActive      = "ACTIVE"
+	UserStatusDeactivated = "DEACTIVATED"
+
+	TemporaryPasswordLength = 12
+
+	DeletedUserName        = "Deleted User"
+	DeletedUserEmailDomain = "deleted.invalid"
+
+	EmailVerificationTokenLength = 32
+	EmailVerificationTTL         = 24 * time.Hour
+)
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateAllergenRequest struct {
+	// Defaults to a slug of the name when empty
+	Code        string `json:"code,omitempty" validate:"omitempty,max=50"`
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description,omitempty"`
+}
+
+type UpdateAllergenRequest struct {
+	Name        string `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description string `json:"description,omitempty"`
+}
+
+type AllergenResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type GetAllergenConflictsQuery struct {
+	Date string `query:"date" example:"30-06-2025"`
+}
+
+// AllergenConflict is a scheduled dish containing allergens declared on a subscription
+type AllergenConflict struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	SubscriberName string    `json:"subscriber_name"`
+	PhoneNumber    string    `json:"phone_number"`
+	AllergyNote    string    `json:"allergy_note"`
+	PlanId         string    `json:"plan_id"`
+	Date           time.Time `json:"date"`
+	DeliveryDay    string    `json:"delivery_day"`
+	Mealtype       string    `json:"mealtype"`
+	DishID         uuid.UUID `json:"dish_id"`
+	DishName       string    `json:"dish_name"`
+	Allergens      []string  `json:"allergens"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/jevvonn/sea-catering-be/internal/domain/entity"
+
+type GetAuditLogQuery struct {
+	Action   string `query:"action"`
+	TargetID string `query:"target_id"`
+	Limit    int    `query:"limit" validate:"omitempty,numeric,min=1,max=100"`
+	Page     int    `query:"page" validate:"omitempty,numeric,min=1"`
+}
+
+type GetAuditLogsResponse struct {
+	AuditLogs []entity.AuditLog `json:"audit_logs"`
+	Total     int64             `json:"total"`
+	Page      int               `json:"page,omitempty"`
+	Limit     int               `json:"limit,omitempty"`
+}
@@ -22,3 +22,8 @@ type SessionResponse struct {
 	Email string `json:"email"`
 	Role  string `json:"role"`
 }
+
+type UnlockLoginRequest struct {
+	Email     string `json:"email,omitempty" validate:"required_without=IPAddress,omitempty,email"`
+	IPAddress string `json:"ip_address,omitempty" validate:"required_without=Email,omitempty,ip"`
+}
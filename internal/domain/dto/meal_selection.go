@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetMealSelectionsQuery struct {
+	Week string `query:"week" example:"30-06-2025"`
+}
+
+type MealSelectionRequest struct {
+	Date     string `json:"date" validate:"required" example:"02-07-2025"`
+	Mealtype string `json:"mealtype" validate:"required,oneof=Breakfast Lunch Dinner"`
+	DishID   string `json:"dish_id" validate:"required,uuid"`
+}
+
+type UpdateMealSelectionsRequest struct {
+	Selections []MealSelectionRequest `json:"selections" validate:"required,min=1,dive"`
+}
+
+type MealOptionResponse struct {
+	IsDefault bool         `json:"is_default"`
+	Dish      DishResponse `json:"dish"`
+
+	// Allergens of the dish the subscriber declared
+	ConflictingAllergens []string `json:"conflicting_allergens"`
+}
+
+type MealSelectionResponse struct {
+	Date        time.Time `json:"date"`
+	DeliveryDay string    `json:"delivery_day"`
+	Mealtype    string    `json:"mealtype"`
+
+	// Selections can be changed until the deadline
+	Deadline time.Time `json:"deadline"`
+	IsLocked bool      `json:"is_locked"`
+
+	// The dish that will be delivered, empty when the menu has no dish for this meal yet
+	DishID        *uuid.UUID `json:"dish_id"`
+	IsDefaultPick bool       `json:"is_default_pick"`
+
+	Options []MealOptionResponse `json:"options"`
+}
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetDishesQuery struct {
+	Search string `query:"search"`
+}
+
+type CreateDishRequest struct {
+	Name        string   `json:"name" validate:"required,max=255"`
+	Description string   `json:"description,omitempty"`
+	Ingredients []string `json:"ingredients" validate:"required,min=1,dive,required"`
+	AllergenIDs []string `json:"allergen_ids,omitempty" validate:"omitempty,dive,uuid"`
+}
+
+type UpdateDishRequest struct {
+	Name        string   `json:"name,omitempty" validate:"omitempty,max=255"`
+	Description string   `json:"description,omitempty"`
+	Ingredients []string `json:"ingredients,omitempty" validate:"omitempty,min=1,dive,required"`
+	AllergenIDs []string `json:"allergen_ids,omitempty" validate:"omitempty,dive,uuid"`
+}
+
+type DishResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Ingredients []string           `json:"ingredients"`
+	Allergens   []AllergenResponse `json:"allergens"`
+}
+
+type GetWeeklyMenuQuery struct {
+	Week string `query:"week" example:"30-06-2025"`
+}
+
+type MenuItemRequest struct {
+	DeliveryDay string `json:"delivery_day" validate:"required,oneof=Monday Tuesday Wednesday Thursday Friday Saturday Sunday"`
+	Mealtype    string `json:"mealtype" validate:"required,oneof=Breakfast Lunch Dinner"`
+	DishID      string `json:"dish_id" validate:"required,uuid"`
+
+	// Served when the subscriber does not pick a dish, defaults to the first dish of the meal
+	IsDefault bool `json:"is_default,omitempty"`
+}
+
+type UpdateWeeklyMenuRequest struct {
+	// Any day of the week, the menu is stored for the Monday of that week
+	Week  string            `json:"week" validate:"required" example:"30-06-2025"`
+	Items []MenuItemRequest `json:"items" validate:"required,dive"`
+}
+
+type MenuOptionResponse struct {
+	IsDefault bool         `json:"is_default"`
+	Dish      DishResponse `json:"dish"`
+}
+
+type MenuMealResponse struct {
+	Mealtype string               `json:"mealtype"`
+	Options  []MenuOptionResponse `json:"options"`
+}
+
+type MenuDayResponse struct {
+	DeliveryDay string             `json:"delivery_day"`
+	Date        time.Time          `json:"date"`
+	Meals       []MenuMealResponse `json:"meals"`
+}
+
+type WeeklyMenuResponse struct {
+	PlanId    string            `json:"plan_id"`
+	WeekStart time.Time         `json:"week_start"`
+	Days      []MenuDayResponse `json:"days"`
+}
+
+type GetProductionQuery struct {
+	Date   string `query:"date" example:"30-06-2025"`
+	Format string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+type ProductionItem struct {
+	PlanId   string     `json:"plan_id"`
+	Mealtype string     `json:"mealtype"`
+	DishID   *uuid.UUID `json:"dish_id"`
+	DishName string     `json:"dish_name"`
+	Portions int        `json:"portions"`
+}
+
+type ProductionDish struct {
+	DishID   *uuid.UUID `json:"dish_id"`
+	DishName string     `json:"dish_name"`
+	Portions int        `json:"portions"`
+}
+
+type ProductionReportResponse struct {
+	Date          time.Time        `json:"date"`
+	DeliveryDay   string           `json:"delivery_day"`
+	TotalPortions int              `json:"total_portions"`
+	Dishes        []ProductionDish `json:"dishes"`
+	Items         []ProductionItem `json:"items"`
+}
@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateOrganizationRequest struct {
+	Name           string `json:"name" validate:"required,max=255"`
+	BillingEmail   string `json:"billing_email" validate:"required,email,max=255"`
+	BillingAddress string `json:"billing_address,omitempty" validate:"omitempty,max=500"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name           string `json:"name,omitempty" validate:"omitempty,max=255"`
+	BillingEmail   string `json:"billing_email,omitempty" validate:"omitempty,email,max=255"`
+	BillingAddress string `json:"billing_address,omitempty" validate:"omitempty,max=500"`
+}
+
+type GetOrganizationsQuery struct {
+	Search string `query:"search"`
+	Limit  int    `query:"limit" validate:"omitempty,numeric,min=1,max=100"`
+	Page   int    `query:"page" validate:"omitempty,numeric,min=1"`
+}
+
+type OrganizationResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	BillingEmail   string    `json:"billing_email"`
+	BillingAddress string    `json:"billing_address"`
+	// Role is the role of the current user, empty for admins who are not a member
+	Role string `json:"role,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GetOrganizationsResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+	Total         int64                  `json:"total"`
+	Page          int                    `json:"page,omitempty"`
+	Limit         int                    `json:"limit,omitempty"`
+}
+
+type AddOrganizationMemberRequest struct {
+	// The member needs an account with this email
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=ORG_ADMIN MEMBER"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=ORG_ADMIN MEMBER"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type MemberSubscriptionRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+
+	// Defaults to the contact details in the member's profile when empty
+	Name        string `json:"name,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+
+	Mealtypes    []string `json:"mealtype,omitempty" validate:"required,min=1,dive,oneof=Breakfast Lunch Dinner"`
+	DeliveryDays []string `json:"delivery_days,omitempty" validate:"required,min=1,dive,oneof=Monday Tuesday Wednesday Thursday Friday Saturday Sunday"`
+
+	AllergenIDs []string `json:"allergen_ids,omitempty" validate:"omitempty,dive,uuid"`
+	AllergyNote string   `json:"allergy_note,omitempty" validate:"omitempty,max=500"`
+}
+
+type CreateOrganizationSubscriptionsRequest struct {
+	PlanId        string                      `json:"plan_id" validate:"required"`
+	Subscriptions []MemberSubscriptionRequest `json:"subscriptions" validate:"required,min=1,max=100,dive"`
+}
+
+type CreateOrganizationSubscriptionsResponse struct {
+	SubscriptionIDs []uuid.UUID `json:"subscription_ids"`
+	MonthlyTotal    float64     `json:"monthly_total"`
+}
+
+type CreateInvoiceRequest struct {
+	Month string `json:"month" validate:"required" example:"06-2025"`
+}
+
+type GetInvoiceQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+type GetOrganizationReportQuery struct {
+	// Months are formatted mm-yyyy, the last 12 months are reported by default
+	StartMonth string `query:"start_month"`
+	EndMonth   string `query:"end_month"`
+	Format     string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+type OrganizationPlanTotal struct {
+	PlanId              string  `json:"plan_id"`
+	PlanName            string  `json:"plan_name"`
+	ActiveSubscriptions int64   `json:"active_subscriptions"`
+	MRR                 float64 `json:"mrr" gorm:"column:mrr"`
+}
+
+type OrganizationMonth struct {
+	MonthStart time.Time `json:"-"`
+	Month      string    `json:"month" gorm:"-"`
+
+	NewSubscriptions int64   `json:"new_subscriptions"`
+	Cancellations    int64   `json:"cancellations"`
+	Invoiced         float64 `json:"invoiced"`
+}
+
+type OrganizationReportResponse struct {
+	OrganizationID      uuid.UUID               `json:"organization_id"`
+	StartMonth          string                  `json:"start_month"`
+	EndMonth            string                  `json:"end_month"`
+	Members             int64                   `json:"members"`
+	ActiveSubscriptions int64                   `json:"active_subscriptions"`
+	MRR                 float64                 `json:"mrr"`
+	Plans               []OrganizationPlanTotal `json:"plans"`
+	Months              []OrganizationMonth     `json:"months"`
+}
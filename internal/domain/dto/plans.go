@@ -1,8 +1,73 @@
 package dto
 
+import "time"
+
+type PlanNutrition struct {
+	CaloriesMin  int     `json:"calories_min" validate:"min=0"`
+	CaloriesMax  int     `json:"calories_max" validate:"min=0,gtefield=CaloriesMin"`
+	ProteinGrams float64 `json:"protein_grams" validate:"min=0"`
+	CarbsGrams   float64 `json:"carbs_grams" validate:"min=0"`
+	FatGrams     float64 `json:"fat_grams" validate:"min=0"`
+}
+
+type CreatePlansRequest struct {
+	ID          string         `json:"id" validate:"required,max=10,lowercase,alphanum"`
+	Name        string         `json:"name" validate:"required"`
+	Slogan      string         `json:"slogan" validate:"required"`
+	Price       float64        `json:"price" validate:"required,numeric,min=1"`
+	Features    []string       `json:"features" validate:"required,min=1,dive,required,max=255"`
+	Tags        []string       `json:"tags,omitempty" validate:"omitempty,dive,oneof=halal vegetarian vegan gluten-free dairy-free"`
+	Nutrition   *PlanNutrition `json:"nutrition,omitempty"`
+	IsAvailable *bool          `json:"is_available,omitempty"`
+}
+
 type UpdatePlansRequest struct {
-	Name     string  `json:"name,omitempty"`
-	Slogan   string  `json:"slogan,omitempty"`
-	Price    float64 `json:"price,omitempty" validate:"numeric,min=1"`
-	Features string  `json:"features,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Slogan      string         `json:"slogan,omitempty"`
+	Price       float64        `json:"price,omitempty" validate:"omitempty,numeric,min=1"`
+	Features    []string       `json:"features,omitempty" validate:"omitempty,min=1,dive,required,max=255"`
+	Tags        []string       `json:"tags,omitempty" validate:"omitempty,dive,oneof=halal vegetarian vegan gluten-free dairy-free"`
+	Nutrition   *PlanNutrition `json:"nutrition,omitempty"`
+	IsAvailable *bool          `json:"is_available,omitempty"`
+}
+
+type ReorderPlansRequest struct {
+	PlanIds []string `json:"plan_ids" validate:"required,min=1,dive,required"`
+}
+
+type PlanTranslationRequest struct {
+	Name     string   `json:"name" validate:"required,max=255"`
+	Slogan   string   `json:"slogan" validate:"required,max=255"`
+	Features []string `json:"features" validate:"required,min=1,dive,required,max=255"`
+}
+
+type PlanTranslationResponse struct {
+	Locale   string   `json:"locale"`
+	Name     string   `json:"name"`
+	Slogan   string   `json:"slogan"`
+	Features []string `json:"features"`
+}
+
+type PlanResponse struct {
+	ID string `json:"id"`
+
+	// Locale of name, slogan and features, the default locale when no translation exists
+	Locale    string        `json:"locale"`
+	Name      string        `json:"name"`
+	Slogan    string        `json:"slogan"`
+	Price     float64       `json:"price"`
+	Features  []string      `json:"features"`
+	Tags      []string      `json:"tags"`
+	Nutrition PlanNutrition `json:"nutrition"`
+	ImageURL  string        `json:"image_url"`
+
+	DisplayOrder int        `json:"display_order"`
+	IsAvailable  bool       `json:"is_available"`
+	ArchivedAt   *time.Time `json:"archived_at"`
+
+	// Only listed for plan managers
+	Translations []PlanTranslationResponse `json:"translations,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,158 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetMRRReportQuery struct {
+	// Months are formatted mm-yyyy, the last 12 months are reported by default
+	StartMonth string `query:"start_month"`
+	EndMonth   string `query:"end_month"`
+	Format     string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+// MRRMonth is the recurring revenue movement of one month. Opening values are
+// taken at the start of the month and closing values at the start of the next.
+// Expansion and contraction are price changes of subscriptions that stay active,
+// like a retention discount starting or ending.
+type MRRMonth struct {
+	MonthStart time.Time `json:"-"`
+	Month      string    `json:"month" gorm:"-"`
+
+	StartingMRR     float64 `json:"starting_mrr"`
+	NewMRR          float64 `json:"new_mrr"`
+	ReactivationMRR float64 `json:"reactivation_mrr"`
+	ExpansionMRR    float64 `json:"expansion_mrr"`
+	ContractionMRR  float64 `json:"contraction_mrr"`
+	ChurnedMRR      float64 `json:"churned_mrr"`
+	NetNewMRR       float64 `json:"net_new_mrr" gorm:"-"`
+	EndingMRR       float64 `json:"ending_mrr"`
+
+	StartingSubscriptions int64 `json:"starting_subscriptions"`
+	NewSubscriptions      int64 `json:"new_subscriptions"`
+	Reactivations         int64 `json:"reactivations"`
+	ChurnedSubscriptions  int64 `json:"churned_subscriptions"`
+	EndingSubscriptions   int64 `json:"ending_subscriptions"`
+
+	// ChurnRate is the percentage of the opening subscriptions cancelled during the month,
+	// RevenueChurnRate the percentage of the opening MRR
+	ChurnRate        float64 `json:"churn_rate" gorm:"-"`
+	RevenueChurnRate float64 `json:"revenue_churn_rate" gorm:"-"`
+}
+
+type MRRReportResponse struct {
+	StartMonth string     `json:"start_month"`
+	EndMonth   string     `json:"end_month"`
+	CurrentMRR float64    `json:"current_mrr"`
+	Months     []MRRMonth `json:"months"`
+}
+
+type GetTimeseriesQuery struct {
+	Metric   string `query:"metric" validate:"required,oneof=new_subscriptions cancellations active_subscriptions revenue"`
+	Interval string `query:"interval" validate:"omitempty,oneof=day week month"`
+	GroupBy  string `query:"group_by" validate:"omitempty,oneof=plan mealtype"`
+	// Dates are formatted dd-mm-yyyy
+	StartDate string `query:"start_date"`
+	EndDate   string `query:"end_date"`
+	Format    string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+// TimeseriesValue is the value of a metric for one group in one bucket
+type TimeseriesValue struct {
+	Bucket   time.Time
+	GroupKey string
+	Value    float64
+}
+
+type TimeseriesPoint struct {
+	Bucket string  `json:"bucket"`
+	Value  float64 `json:"value"`
+}
+
+type TimeseriesSeries struct {
+	Group  string            `json:"group"`
+	Points []TimeseriesPoint `json:"points"`
+}
+
+type TimeseriesResponse struct {
+	Metric    string             `json:"metric"`
+	Interval  string             `json:"interval"`
+	GroupBy   string             `json:"group_by,omitempty"`
+	StartDate string             `json:"start_date"`
+	EndDate   string             `json:"end_date"`
+	Series    []TimeseriesSeries `json:"series"`
+}
+
+type GetCohortReportQuery struct {
+	// Months are formatted mm-yyyy, cohorts of the last 12 months are reported by default
+	StartMonth string `query:"start_month"`
+	EndMonth   string `query:"end_month"`
+	PlanId     string `query:"plan_id" validate:"omitempty,max=10"`
+	Format     string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+// CohortRetentionCount is how many users of a cohort were active a number of months
+// after their first subscription, out of those for whom that moment has passed
+type CohortRetentionCount struct {
+	CohortMonth   time.Time
+	MonthOffset   int
+	EligibleUsers int64
+	ActiveUsers   int64
+}
+
+type CohortResponse struct {
+	Cohort string `json:"cohort"`
+	Users  int64  `json:"users"`
+	// Retention holds the percentage of users still subscribed 1 to 12 months after
+	// their first subscription, null for months that have not passed yet
+	Retention []*float64 `json:"retention"`
+}
+
+type CohortReportResponse struct {
+	StartMonth string           `json:"start_month"`
+	EndMonth   string           `json:"end_month"`
+	PlanId     string           `json:"plan_id,omitempty"`
+	Cohorts    []CohortResponse `json:"cohorts"`
+}
+
+type GetChurnReasonsQuery struct {
+	// Months are formatted mm-yyyy, requests of the last 12 months are reported by default
+	StartMonth string `query:"start_month"`
+	EndMonth   string `query:"end_month"`
+	Format     string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+// ChurnReason sums up the cancellation requests given for one reason. Cancellations include
+// the ones scheduled for the end of the billing period, Retained the accepted offers.
+type ChurnReason struct {
+	Reason        string  `json:"reason"`
+	Cancellations int64   `json:"cancellations"`
+	ChurnedMRR    float64 `json:"churned_mrr"`
+	OffersMade    int64   `json:"offers_made"`
+	Retained      int64   `json:"retained"`
+
+	// Share is the percentage of all cancellations, SaveRate the percentage of offers accepted
+	Share    float64 `json:"share" gorm:"-"`
+	SaveRate float64 `json:"save_rate" gorm:"-"`
+}
+
+type ChurnNote struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Reason         string    `json:"reason"`
+	Outcome        string    `json:"outcome"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ChurnReasonsResponse struct {
+	StartMonth         string        `json:"start_month"`
+	EndMonth           string        `json:"end_month"`
+	TotalCancellations int64         `json:"total_cancellations"`
+	TotalChurnedMRR    float64       `json:"total_churned_mrr"`
+	TotalRetained      int64         `json:"total_retained"`
+	Reasons            []ChurnReason `json:"reasons"`
+	// Notes are the latest free text explanations, newest first
+	Notes []ChurnNote `json:"notes"`
+}
@@ -10,19 +10,27 @@ import (
 type CreateSubscriptionRequest struct {
 	PlanId string `json:"plan_id,omitempty" validate:"required"`
 
-	Name        string `json:"name,omitempty" validate:"required"`
-	PhoneNumber string `json:"phone_number,omitempty" validate:"required"`
+	// Defaults to the contact details in the user's profile when empty
+	Name        string `json:"name,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
 
 	Mealtypes    []string `json:"mealtype,omitempty" validate:"required,min=1,dive,oneof=Breakfast Lunch Dinner"`
 	DeliveryDays []string `json:"delivery_days,omitempty" validate:"required,min=1,dive,oneof=Monday Tuesday Wednesday Thursday Friday Saturday Sunday"`
-	Allergies    []string `json:"allergies,omitempty" validate:"required,dive"`
+
+	AllergenIDs []string `json:"allergen_ids,omitempty" validate:"omitempty,dive,uuid"`
+	AllergyNote string   `json:"allergy_note,omitempty" validate:"omitempty,max=500"`
 }
 
 type UpdateSubscriptionRequest struct {
 	Name        string `json:"name,omitempty"`
 	PhoneNumber string `json:"phone_number,omitempty"`
 
-	Status         string `json:"status,omitempty" validate:"omitempty,oneof=ACTIVE CANCELLED"`
+	// Replace the declared allergens and the note when present, an empty list or note clears them
+	AllergenIDs []string `json:"allergen_ids,omitempty" validate:"omitempty,dive,uuid"`
+	AllergyNote *string  `json:"allergy_note,omitempty" validate:"omitempty,max=500"`
+
+	// ACTIVE resumes a paused subscription, cancelling goes through the cancellation flow
+	Status         string `json:"status,omitempty" validate:"omitempty,oneof=ACTIVE"`
 	PauseStartDate string `json:"pause_start_date,omitempty" example:"27-06-2025"`
 	PauseEndDate   string `json:"pause_end_date,omitempty" example:"30-06-2025"`
 }
@@ -33,6 +41,8 @@ type GetSubscriptionResponse struct {
 	UserID uuid.UUID       `json:"user_id,omitempty"`
 	User   GetUserResponse `json:"user,omitempty"`
 
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+
 	PlanId string       `json:"plan_id,omitempty"`
 	Plans  entity.Plans `json:"plan,omitempty"`
 
@@ -41,7 +51,9 @@ type GetSubscriptionResponse struct {
 
 	Mealtypes    []string `json:"mealtype"`
 	DeliveryDays []string `json:"delivery_days"`
-	Allergies    []string `json:"allergies"`
+
+	Allergens   []AllergenResponse `json:"allergens"`
+	AllergyNote string             `json:"allergy_note"`
 
 	TotalPrice float64 `json:"total_price"`
 
@@ -49,14 +61,118 @@ type GetSubscriptionResponse struct {
 	IsPaused       bool       `json:"is_paused"`
 	PauseStartDate *time.Time `json:"pause_start_date"`
 	PauseEndDate   *time.Time `json:"pause_end_date"`
+	CancelAt       *time.Time `json:"cancel_at"`
+
+	DiscountPercent int        `json:"discount_percent"`
+	DiscountEndsAt  *time.Time `json:"discount_ends_at"`
 
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 }
 
+type GetRetentionOfferQuery struct {
+	Reason string `query:"reason" validate:"required,oneof=TOO_EXPENSIVE NOT_USING_ENOUGH FOOD_QUALITY DIETARY_NEEDS DELIVERY_ISSUES MOVING TEMPORARY_BREAK OTHER"`
+}
+
+// RetentionOfferResponse describes the offer made instead of cancelling, Type is empty when there is none
+type RetentionOfferResponse struct {
+	Type string `json:"type"`
+
+	DiscountPercent int        `json:"discount_percent,omitempty"`
+	DiscountedPrice float64    `json:"discounted_price,omitempty"`
+	DiscountEndsAt  *time.Time `json:"discount_ends_at,omitempty"`
+
+	PauseStartDate *time.Time `json:"pause_start_date,omitempty"`
+	PauseEndDate   *time.Time `json:"pause_end_date,omitempty"`
+}
+
+type CancelSubscriptionRequest struct {
+	Reason string `json:"reason" validate:"required,oneof=TOO_EXPENSIVE NOT_USING_ENOUGH FOOD_QUALITY DIETARY_NEEDS DELIVERY_ISSUES MOVING TEMPORARY_BREAK OTHER"`
+	Note   string `json:"note,omitempty" validate:"omitempty,max=1000"`
+
+	// AtPeriodEnd keeps the subscription running until the end of the current billing period
+	AtPeriodEnd bool `json:"at_period_end"`
+	// AcceptOffer takes the retention offer for the reason instead of cancelling
+	AcceptOffer bool `json:"accept_offer"`
+}
+
+type CancelSubscriptionResponse struct {
+	Outcome     string                  `json:"outcome"`
+	EffectiveAt *time.Time              `json:"effective_at,omitempty"`
+	Offer       *RetentionOfferResponse `json:"offer,omitempty"`
+}
+
+type GetSubscriptionsQuery struct {
+	Status      string `query:"status" validate:"omitempty,oneof=ACTIVE CANCELLED"`
+	PlanId      string `query:"plan_id" validate:"omitempty,max=10"`
+	Paused      string `query:"paused" validate:"omitempty,oneof=true false"`
+	Mealtype    string `query:"mealtype" validate:"omitempty,oneof=Breakfast Lunch Dinner"`
+	DeliveryDay string `query:"delivery_day" validate:"omitempty,oneof=Monday Tuesday Wednesday Thursday Friday Saturday Sunday"`
+	// Dates are formatted dd-mm-yyyy, both ends are inclusive
+	CreatedFrom string `query:"created_from" example:"01-06-2025"`
+	CreatedTo   string `query:"created_to" example:"30-06-2025"`
+	Search      string `query:"search"`
+	Sort        string `query:"sort" validate:"omitempty,oneof=newest oldest price_high price_low"`
+	Limit       int    `query:"limit" validate:"omitempty,numeric,min=1,max=100"`
+	Page        int    `query:"page" validate:"omitempty,numeric,min=1"`
+	Format      string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
+// SubscriptionFilter is the parsed form of GetSubscriptionsQuery used by the repository
+type SubscriptionFilter struct {
+	Status      string
+	PlanId      string
+	Paused      *bool
+	Mealtype    string
+	DeliveryDay string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Search      string
+	Sort        string
+	Limit       int
+	Page        int
+}
+
+type GetSubscriptionsResponse struct {
+	Subscriptions []GetSubscriptionResponse `json:"subscriptions"`
+	Total         int64                     `json:"total"`
+	Page          int                       `json:"page,omitempty"`
+	Limit         int                       `json:"limit,omitempty"`
+}
+
+type SearchSubscriptionsQuery struct {
+	Q     string `query:"q" validate:"required,min=2,max=100"`
+	Limit int    `query:"limit" validate:"omitempty,numeric,min=1,max=50"`
+}
+
+type SubscriptionSearchResult struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	UserName    string    `json:"user_name"`
+	UserEmail   string    `json:"user_email"`
+	PlanId      string    `json:"plan_id"`
+	PlanName    string    `json:"plan_name"`
+	Name        string    `json:"name"`
+	PhoneNumber string    `json:"phone_number"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Score is the trigram similarity of the best matching field, from 0 to 1
+	Score float64 `json:"score"`
+	// Highlights holds the matching fields with the matches wrapped in <mark> tags,
+	// the rest of the value is HTML escaped
+	Highlights map[string]string `json:"highlights" gorm:"-"`
+}
+
+type GetSubscriptionReportQuery struct {
+	StartDate string `query:"start_date"`
+	EndDate   string `query:"end_date"`
+	Format    string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
+}
+
 type GetSubscriptionReportResponse struct {
-	ActiveSubscriptionsByDate int     `json:"active_subscriptions_by_date"`
+	ActiveSubscriptionsByDate int64   `json:"active_subscriptions_by_date"`
 	TotalRevenue              float64 `json:"total_revenue"`
-	TotalActiveSubscriptions  int     `json:"total_active_subscriptions"`
+	TotalActiveSubscriptions  int64   `json:"total_active_subscriptions"`
 	TotalRevenueByDate        float64 `json:"total_revenue_by_date"`
 }
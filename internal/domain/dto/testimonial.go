@@ -1,21 +1,117 @@
 package dto
 
-import "github.com/jevvonn/sea-catering-be/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+)
 
 type TestimonialRequest struct {
-	Name    string  `json:"name,omitempty" validate:"required"`
+	// Name is required for guests, signed in customers are named after their account
+	Name    string  `json:"name,omitempty" validate:"omitempty,max=255"`
+	PlanId  string  `json:"plan_id,omitempty" validate:"omitempty,max=10"`
+	Message string  `json:"message,omitempty" validate:"required"`
+	Rating  float64 `json:"rating,omitempty" validate:"required,min=1,max=5,numeric"`
+
+	// ChallengeToken comes from GET /testimonials/challenge, is required for guests and can be used once
+	ChallengeToken string `json:"challenge_token,omitempty"`
+	// Website is a honeypot field hidden from people, only bots fill it in
+	Website string `json:"website,omitempty"`
+}
+
+type TestimonialChallengeResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type UpdateTestimonialRequest struct {
 	Message string  `json:"message,omitempty" validate:"required"`
 	Rating  float64 `json:"rating,omitempty" validate:"required,min=1,max=5,numeric"`
 }
 
 type GetTestimonialQuery struct {
-	Limit int `query:"limit" validate:"omitempty,numeric,min=1,max=100"`
-	Page  int `query:"page" validate:"omitempty,numeric,min=1"`
+	Limit     int     `query:"limit" validate:"omitempty,numeric,min=1,max=100"`
+	Page      int     `query:"page" validate:"omitempty,numeric,min=1"`
+	Sort      string  `query:"sort" validate:"omitempty,oneof=newest highest lowest"`
+	Rating    int     `query:"rating" validate:"omitempty,min=1,max=5"`
+	MinRating float64 `query:"min_rating" validate:"omitempty,min=1,max=5"`
+	PlanId    string  `query:"plan_id" validate:"omitempty,max=10"`
+	Status    string  `query:"-"`
+	Flagged   bool    `query:"-"`
+}
+
+type GetModerationQueueQuery struct {
+	Limit   int    `query:"limit" validate:"omitempty,numeric,min=1,max=100"`
+	Page    int    `query:"page" validate:"omitempty,numeric,min=1"`
+	Status  string `query:"status" validate:"omitempty,oneof=PENDING APPROVED REJECTED HIDDEN"`
+	Flagged bool   `query:"flagged"`
+}
+
+type ModerateTestimonialRequest struct {
+	Status string `json:"status" validate:"required,oneof=APPROVED REJECTED HIDDEN"`
+}
+
+type ReplyTestimonialRequest struct {
+	Message string `json:"message" validate:"required,max=2000"`
+	// Notify emails the reviewer when they wrote the testimonial from their account
+	Notify bool `json:"notify"`
+}
+
+// TestimonialResponse is an approved testimonial as the public sees it, without moderation details
+type TestimonialResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Message    string    `json:"message"`
+	Rating     float64   `json:"rating"`
+	PlanId     *string   `json:"plan_id,omitempty"`
+	IsVerified bool      `json:"is_verified"`
+
+	ReplyMessage string     `json:"reply_message,omitempty"`
+	ReplyAuthor  string     `json:"reply_author,omitempty"`
+	RepliedAt    *time.Time `json:"replied_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GetPublicTestimonialsResponse struct {
+	Testimonials []TestimonialResponse `json:"testimonials"`
+	Total        int64                 `json:"total"`
+	Page         int                   `json:"page,omitempty"`
+	Limit        int                   `json:"limit,omitempty"`
 }
 
 type GetTestimonialsResponse struct {
 	Testimonials []entity.Testimonial `json:"testimonials"`
-	Total        int                  `json:"total,omitempty"`
+	Total        int64                `json:"total"`
 	Page         int                  `json:"page,omitempty"`
 	Limit        int                  `json:"limit,omitempty"`
 }
+
+// TestimonialRatingCount is the number of approved testimonials of a plan with a star rating
+type TestimonialRatingCount struct {
+	PlanId    *string
+	PlanName  string
+	Stars     int
+	Count     int64
+	RatingSum float64
+}
+
+type RatingSummary struct {
+	Count   int64   `json:"count"`
+	Average float64 `json:"average"`
+	// Histogram counts testimonials per star, ratings are rounded to whole stars
+	Histogram map[int]int64 `json:"histogram"`
+}
+
+type PlanRatingSummary struct {
+	PlanId   string `json:"plan_id"`
+	PlanName string `json:"plan_name"`
+	RatingSummary
+}
+
+type TestimonialStatsResponse struct {
+	Overall RatingSummary       `json:"overall"`
+	Plans   []PlanRatingSummary `json:"plans"`
+}
@@ -1,9 +1,139 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type GetUserResponse struct {
 	ID    uuid.UUID `json:"id,omitempty"`
 	Email string    `json:"email,omitempty"`
 	Name  string    `json:"name,omitempty"`
 }
+
+type GetUsersQuery struct {
+	Search string `query:"search"`
+	Role   string `query:"role"`
+	Status string `query:"status" validate:"omitempty,oneof=ACTIVE DEACTIVATED"`
+	Limit  int    `query:"limit" validate:"omitempty,numeric,min=1,max=100"`
+	Page   int    `query:"page" validate:"omitempty,numeric,min=1"`
+}
+
+type UserResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	IsActive      bool       `json:"is_active"`
+	DeactivatedAt *time.Time `json:"deactivated_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GetUsersResponse struct {
+	Users []UserResponse `json:"users"`
+	Total int64          `json:"total"`
+	Page  int            `json:"page,omitempty"`
+	Limit int            `json:"limit,omitempty"`
+}
+
+type UserSubscriptionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	PlanId     string    `json:"plan_id"`
+	PlanName   string    `json:"plan_name"`
+	Status     string    `json:"status"`
+	TotalPrice float64   `json:"total_price"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GetUserDetailResponse struct {
+	UserResponse
+	Subscriptions []UserSubscriptionResponse `json:"subscriptions"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type ProfileResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Role         string    `json:"role"`
+	ContactName  string    `json:"contact_name"`
+	PhoneNumber  string    `json:"phone_number"`
+	PendingEmail string    `json:"pending_email,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UpdateProfileRequest struct {
+	Name        string `json:"name,omitempty" validate:"omitempty,max=255"`
+	ContactName string `json:"contact_name,omitempty" validate:"omitempty,max=255"`
+	PhoneNumber string `json:"phone_number,omitempty" validate:"omitempty,max=20"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=15"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type ExportUserDataQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=json zip"`
+}
+
+type ExportSubscription struct {
+	ID             uuid.UUID  `json:"id"`
+	PlanId         string     `json:"plan_id"`
+	PlanName       string     `json:"plan_name"`
+	Name           string     `json:"name"`
+	PhoneNumber    string     `json:"phone_number"`
+	Mealtypes      []string   `json:"mealtype"`
+	DeliveryDays   []string   `json:"delivery_days"`
+	Allergens      []string   `json:"allergens"`
+	AllergyNote    string     `json:"allergy_note"`
+	TotalPrice     float64    `json:"total_price"`
+	Status         string     `json:"status"`
+	PauseStartDate *time.Time `json:"pause_start_date"`
+	PauseEndDate   *time.Time `json:"pause_end_date"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type ExportTestimonial struct {
+	ID         uuid.UUID `json:"id"`
+	PlanId     string    `json:"plan_id"`
+	Name       string    `json:"name"`
+	Message    string    `json:"message"`
+	Rating     float64   `json:"rating"`
+	Status     string    `json:"status"`
+	IsVerified bool      `json:"is_verified"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type UserDataExport struct {
+	ExportedAt    time.Time            `json:"exported_at"`
+	Profile       ProfileResponse      `json:"profile"`
+	Subscriptions []ExportSubscription `json:"subscriptions"`
+	Testimonials  []ExportTestimonial  `json:"testimonials"`
+}
+
+type ResetUserPasswordResponse struct {
+	TemporaryPassword string `json:"temporary_password"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Allergen struct {
+	ID          uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+	Code        string    `gorm:"type:varchar(50);not null;uniqueIndex" json:"code,omitempty"`
+	Name        string    `gorm:"type:varchar(100);not null" json:"name,omitempty"`
+	Description string    `gorm:"type:text;not null;default:''" json:"description,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLog struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	ActorID *uuid.UUID `gorm:"type:uuid;index" json:"actor_id,omitempty"`
+
+	Action     string `gorm:"type:varchar(100);not null;index" json:"action,omitempty"`
+	TargetType string `gorm:"type:varchar(50);not null" json:"target_type,omitempty"`
+	TargetID   string `gorm:"type:varchar(255);not null" json:"target_id,omitempty"`
+	IPAddress  string `gorm:"type:varchar(100)" json:"ip_address,omitempty"`
+	Details    string `gorm:"type:text" json:"details,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at,omitempty"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invoice bills the subscriptions of an organization for one month
+type Invoice struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_invoice_organization_period" json:"organization_id,omitempty"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	Number      string    `gorm:"type:varchar(50);not null;uniqueIndex" json:"number,omitempty"`
+	PeriodStart time.Time `gorm:"type:date;not null;uniqueIndex:idx_invoice_organization_period" json:"period_start,omitempty"`
+	PeriodEnd   time.Time `gorm:"type:date;not null" json:"period_end,omitempty"`
+
+	Total  float64 `gorm:"type:decimal(12,2);not null" json:"total"`
+	Status string  `gorm:"type:varchar(20);not null" json:"status,omitempty"`
+
+	IssuedAt time.Time  `gorm:"not null" json:"issued_at,omitempty"`
+	PaidAt   *time.Time `json:"paid_at,omitempty"`
+
+	Lines []InvoiceLine `gorm:"foreignKey:InvoiceID" json:"lines,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// InvoiceLine is one subscription on an invoice. The member and plan are copied so
+// the invoice stays the same when the subscription changes.
+type InvoiceLine struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	InvoiceID uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	Invoice   Invoice   `gorm:"foreignKey:InvoiceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;index" json:"subscription_id,omitempty"`
+
+	MemberName   string `gorm:"type:varchar(255);not null" json:"member_name,omitempty"`
+	MemberEmail  string `gorm:"type:varchar(255);not null" json:"member_email,omitempty"`
+	PlanName     string `gorm:"type:varchar(255);not null" json:"plan_name,omitempty"`
+	Mealtypes    string `gorm:"type:text;not null" json:"mealtype,omitempty"`
+	DeliveryDays string `gorm:"type:text;not null" json:"delivery_days,omitempty"`
+
+	Price           float64 `gorm:"type:decimal(10,2);not null" json:"price"`
+	DiscountPercent int     `gorm:"not null;default:0" json:"discount_percent"`
+	Amount          float64 `gorm:"type:decimal(10,2);not null" json:"amount"`
+}
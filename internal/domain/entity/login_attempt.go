@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LoginAttempt struct {
+	ID    uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+	Scope string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_login_attempt_scope_key" json:"scope,omitempty"`
+	Key   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_login_attempt_scope_key" json:"key,omitempty"`
+
+	FailedCount  int        `gorm:"not null;default:0" json:"failed_count"`
+	LastFailedAt *time.Time `gorm:"type:timestamp" json:"last_failed_at,omitempty"`
+	LockedUntil  *time.Time `gorm:"type:timestamp" json:"locked_until,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MealSelection is the dish a subscriber picked for one meal of one delivery date
+type MealSelection struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	SubscriptionID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_meal_selection_slot" json:"subscription_id,omitempty"`
+	Subscription   Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"subscription,omitempty"`
+
+	DeliveryDate time.Time `gorm:"type:date;not null;uniqueIndex:idx_meal_selection_slot" json:"delivery_date,omitempty"`
+	Mealtype     string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_meal_selection_slot" json:"mealtype,omitempty"`
+
+	DishID uuid.UUID `gorm:"type:uuid;not null;index" json:"dish_id,omitempty"`
+	Dish   Dish      `gorm:"foreignKey:DishID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"dish,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
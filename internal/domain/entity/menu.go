@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Dish struct {
+	ID          uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+	Name        string    `gorm:"type:varchar(255);not null" json:"name,omitempty"`
+	Description string    `gorm:"type:text;not null;default:''" json:"description,omitempty"`
+	Ingredients string    `gorm:"type:text;not null;default:''" json:"ingredients,omitempty"`
+
+	Allergens []Allergen `gorm:"many2many:dish_allergens;" json:"allergens,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// MenuItem offers a dish to a plan for one meal of one delivery day in a week.
+// A meal can offer several dishes, the default one is served when the
+// subscriber does not pick another.
+type MenuItem struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	PlanId string `gorm:"type:varchar(10);not null;uniqueIndex:idx_menu_item_option" json:"plan_id,omitempty"`
+	Plans  Plans  `gorm:"foreignKey:PlanId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"plan,omitempty"`
+
+	WeekStart   time.Time `gorm:"type:date;not null;uniqueIndex:idx_menu_item_option" json:"week_start,omitempty"`
+	DeliveryDay string    `gorm:"type:varchar(10);not null;uniqueIndex:idx_menu_item_option" json:"delivery_day,omitempty"`
+	Mealtype    string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_menu_item_option" json:"mealtype,omitempty"`
+
+	DishID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_menu_item_option" json:"dish_id,omitempty"`
+	Dish   Dish      `gorm:"foreignKey:DishID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"dish,omitempty"`
+
+	IsDefault bool `gorm:"not null;default:false" json:"is_default"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization is a company ordering subscriptions for its members under one bill
+type Organization struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	Name           string `gorm:"type:varchar(255);not null" json:"name,omitempty"`
+	BillingEmail   string `gorm:"type:varchar(255);not null" json:"billing_email,omitempty"`
+	BillingAddress string `gorm:"type:text;not null;default:''" json:"billing_address,omitempty"`
+
+	Members []OrganizationMember `gorm:"foreignKey:OrganizationID" json:"members,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// OrganizationMember links a user to their organization, a user belongs to at most one
+type OrganizationMember struct {
+	OrganizationID uuid.UUID    `gorm:"type:uuid;primaryKey" json:"organization_id,omitempty"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	UserID uuid.UUID `gorm:"type:uuid;primaryKey;uniqueIndex" json:"user_id,omitempty"`
+	User   User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"user,omitempty"`
+
+	Role string `gorm:"type:varchar(20);not null" json:"role,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
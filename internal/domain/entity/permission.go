@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type Permission struct {
+	ID          string `gorm:"primaryKey;type:varchar(100)" json:"id,omitempty"`
+	Description string `gorm:"type:varchar(255);not null" json:"description,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+type RolePermission struct {
+	Role         string     `gorm:"primaryKey;type:varchar(50)" json:"role,omitempty"`
+	PermissionID string     `gorm:"primaryKey;type:varchar(100)" json:"permission_id,omitempty"`
+	Permission   Permission `gorm:"foreignKey:PermissionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"permission,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
@@ -1,13 +1,65 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Plans struct {
-	ID       string  `gorm:"primaryKey;type:varchar(10)" json:"id,omitempty"`
-	Name     string  `gorm:"type:varchar(255);not null" json:"name,omitempty"`
-	Slogan   string  `gorm:"type:varchar(255);not null" json:"slogan,omitempty"`
-	Price    float64 `gorm:"type:float;not null" json:"price,omitempty"`
-	Features string  `gorm:"type:text;not null" json:"features,omitempty"`
+	ID       string        `gorm:"primaryKey;type:varchar(10)" json:"id,omitempty"`
+	Name     string        `gorm:"type:varchar(255);not null" json:"name,omitempty"`
+	Slogan   string        `gorm:"type:varchar(255);not null" json:"slogan,omitempty"`
+	Price    float64       `gorm:"type:float;not null" json:"price,omitempty"`
+	Features []PlanFeature `gorm:"foreignKey:PlanId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"features,omitempty"`
+	Tags     []PlanTag     `gorm:"foreignKey:PlanId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"tags,omitempty"`
+
+	Translations []PlanTranslation `gorm:"foreignKey:PlanId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"translations,omitempty"`
+
+	Nutrition PlanNutrition `gorm:"embedded;embeddedPrefix:nutrition_" json:"nutrition"`
+
+	ImageURL string `gorm:"type:varchar(500);not null;default:''" json:"image_url,omitempty"`
+	ImageKey string `gorm:"type:varchar(255);not null;default:''" json:"-"`
+
+	DisplayOrder int  `gorm:"not null;default:0" json:"display_order"`
+	IsAvailable  bool `gorm:"not null;default:true" json:"is_available"`
+
+	// Archived plans are hidden from the catalogue but kept so existing subscriptions still reference them
+	ArchivedAt *time.Time `gorm:"type:timestamp" json:"archived_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// PlanNutrition describes a single meal of the plan
+type PlanNutrition struct {
+	CaloriesMin  int     `gorm:"not null;default:0" json:"calories_min"`
+	CaloriesMax  int     `gorm:"not null;default:0" json:"calories_max"`
+	ProteinGrams float64 `gorm:"type:float;not null;default:0" json:"protein_grams"`
+	CarbsGrams   float64 `gorm:"type:float;not null;default:0" json:"carbs_grams"`
+	FatGrams     float64 `gorm:"type:float;not null;default:0" json:"fat_grams"`
+}
+
+// PlanFeature is a line of the plan's feature list in one locale
+type PlanFeature struct {
+	ID       uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+	PlanId   string    `gorm:"type:varchar(10);not null;index" json:"plan_id,omitempty"`
+	Locale   string    `gorm:"type:varchar(5);not null;default:'en'" json:"locale,omitempty"`
+	Position int       `gorm:"not null" json:"position"`
+	Text     string    `gorm:"type:varchar(255);not null" json:"text,omitempty"`
+}
+
+type PlanTag struct {
+	PlanId string `gorm:"primaryKey;type:varchar(10)" json:"plan_id,omitempty"`
+	Tag    string `gorm:"primaryKey;type:varchar(50)" json:"tag,omitempty"`
+}
+
+// PlanTranslation holds the plan name and slogan in a locale other than the default one
+type PlanTranslation struct {
+	PlanId string `gorm:"primaryKey;type:varchar(10)" json:"plan_id,omitempty"`
+	Locale string `gorm:"primaryKey;type:varchar(5)" json:"locale,omitempty"`
+	Name   string `gorm:"type:varchar(255);not null" json:"name,omitempty"`
+	Slogan string `gorm:"type:varchar(255);not null" json:"slogan,omitempty"`
 
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
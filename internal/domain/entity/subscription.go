@@ -10,7 +10,11 @@ type Subscription struct {
 	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
 
 	UserID uuid.UUID `gorm:"type:uuid;not null;" json:"user_id,omitempty"`
-	User   User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"user,omitempty"`
+	User   User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"user,omitempty"`
+
+	// OrganizationID is set when the subscription is billed to the organization of the user
+	OrganizationID *uuid.UUID    `gorm:"type:uuid;index" json:"organization_id,omitempty"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
 
 	PlanId string `gorm:"type:varchar(10);not null;" json:"plan_id,omitempty"`
 	Plans  Plans  `gorm:"foreignKey:PlanId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"plan,omitempty"`
@@ -20,13 +24,23 @@ type Subscription struct {
 
 	Mealtypes    string `gorm:"type:text;not null" json:"mealtype,omitempty"`
 	DeliveryDays string `gorm:"type:text;not null" json:"delivery_days,omitempty"`
-	Allergies    string `gorm:"type:text;not null" json:"allergies,omitempty"`
+
+	Allergens   []Allergen `gorm:"many2many:subscription_allergens;" json:"allergens,omitempty"`
+	AllergyNote string     `gorm:"type:text;not null;default:''" json:"allergy_note,omitempty"`
 
 	TotalPrice float64 `gorm:"type:decimal(10,2);not null" json:"total_price,omitempty"`
 
 	Status         string     `gorm:"type:varchar(50);not null;default:'ACTIVE'" json:"status,omitempty"`
 	PauseStartDate *time.Time `gorm:"type:timestamp" json:"pause_start_date,omitempty"`
 	PauseEndDate   *time.Time `gorm:"type:timestamp" json:"pause_end_date,omitempty"`
+	CancelledAt    *time.Time `json:"cancelled_at,omitempty"`
+	// CancelAt is when a cancellation scheduled for the end of the billing period takes effect
+	CancelAt *time.Time `gorm:"index" json:"cancel_at,omitempty"`
+
+	// A discount accepted as retention offer, applied to the price until it ends
+	DiscountPercent  int        `gorm:"not null;default:0" json:"discount_percent,omitempty"`
+	DiscountEndsAt   *time.Time `json:"discount_ends_at,omitempty"`
+	RetentionOfferAt *time.Time `json:"retention_offer_at,omitempty"`
 
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionCancellation records a cancellation request with the reason given,
+// the retention offer made and what came of it
+type SubscriptionCancellation struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	SubscriptionID uuid.UUID    `gorm:"type:uuid;not null;index" json:"subscription_id,omitempty"`
+	Subscription   Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	Reason    string  `gorm:"type:varchar(50);not null" json:"reason,omitempty"`
+	Note      string  `gorm:"type:text;not null;default:''" json:"note,omitempty"`
+	OfferType string  `gorm:"type:varchar(20);not null;default:''" json:"offer_type,omitempty"`
+	Outcome   string  `gorm:"type:varchar(20);not null;index" json:"outcome,omitempty"`
+	MRR       float64 `gorm:"column:mrr;type:decimal(10,2);not null" json:"mrr"`
+
+	// EffectiveAt is when the subscription ends, empty when it was retained
+	EffectiveAt *time.Time `json:"effective_at,omitempty"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionEvent records a status change of a subscription together with its
+// monthly value at that moment, revenue analytics are computed from this history
+type SubscriptionEvent struct {
+	ID uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+
+	SubscriptionID uuid.UUID    `gorm:"type:uuid;not null;index" json:"subscription_id,omitempty"`
+	Subscription   Subscription `gorm:"foreignKey:SubscriptionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	Type       string    `gorm:"type:varchar(20);not null" json:"type,omitempty"`
+	MRR        float64   `gorm:"column:mrr;type:decimal(10,2);not null" json:"mrr"`
+	OccurredAt time.Time `gorm:"not null;index" json:"occurred_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
@@ -12,6 +12,25 @@ type Testimonial struct {
 	Message string    `gorm:"type:text;not null;" json:"message,omitempty"`
 	Rating  float64   `gorm:"type:float;not null;" json:"rating,omitempty"`
 
+	// UserID and PlanId are set when a signed in customer writes the review
+	UserID     *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_testimonial_user_plan" json:"-"`
+	User       *User      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	PlanId     *string    `gorm:"type:varchar(10);uniqueIndex:idx_testimonial_user_plan" json:"plan_id,omitempty"`
+	Plans      *Plans     `gorm:"foreignKey:PlanId;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	IsVerified bool       `gorm:"not null;default:false" json:"is_verified"`
+
+	Status      string     `gorm:"type:varchar(20);not null;default:'PENDING';index" json:"status,omitempty"`
+	ModeratedBy *uuid.UUID `gorm:"type:uuid" json:"moderated_by,omitempty"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+	// FlagReason explains why spam checks sent the testimonial to moderation
+	FlagReason string `gorm:"type:text;not null;default:''" json:"flag_reason,omitempty"`
+
+	// Public reply of the SEA Catering team
+	ReplyMessage  string     `gorm:"type:text;not null;default:''" json:"reply_message,omitempty"`
+	ReplyAuthor   string     `gorm:"type:varchar(255);not null;default:''" json:"reply_author,omitempty"`
+	ReplyAuthorID *uuid.UUID `gorm:"type:uuid" json:"-"`
+	RepliedAt     *time.Time `json:"replied_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 }
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestimonialChallenge records a challenge token that was used for a testimonial, so it
+// can not be replayed before it expires. ID is the jti claim of the token.
+type TestimonialChallenge struct {
+	ID        uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
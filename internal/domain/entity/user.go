@@ -10,9 +10,23 @@ type User struct {
 	ID       uuid.UUID `gorm:"primaryKey" json:"id,omitempty"`
 	Name     string    `gorm:"type:varchar(255);not null" json:"name,omitempty"`
 	Email    string    `gorm:"type:varchar(255);index;not null;unique" json:"email,omitempty"`
-	Password string    `gorm:"type:varchar(255);not null;"`
+	Password string    `gorm:"type:varchar(255);not null;" json:"-"`
 	Role     string    `gorm:"type:varchar(255);default:'USER'" json:"role,omitempty"`
 
+	// Default contact details pre-filled into new subscriptions
+	ContactName string `gorm:"type:varchar(255);not null;default:''" json:"contact_name,omitempty"`
+	PhoneNumber string `gorm:"type:varchar(255);not null;default:''" json:"phone_number,omitempty"`
+
+	PendingEmail               string     `gorm:"type:varchar(255);not null;default:''" json:"pending_email,omitempty"`
+	EmailVerificationToken     string     `gorm:"type:varchar(255);not null;default:''" json:"-"`
+	EmailVerificationExpiresAt *time.Time `gorm:"type:timestamp" json:"-"`
+
+	IsActive      bool       `gorm:"not null;default:true" json:"is_active"`
+	DeactivatedAt *time.Time `gorm:"type:timestamp" json:"deactivated_at,omitempty"`
+
+	// Deleted accounts are anonymized instead of removed so their subscriptions keep counting towards revenue
+	AnonymizedAt *time.Time `gorm:"type:timestamp" json:"anonymized_at,omitempty"`
+
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 }
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Location is the timezone timestamps are exported in. Jakarta has no daylight
+// saving time, so a fixed offset is a safe fallback when tzdata is missing.
+var Location = loadLocation()
+
+func loadLocation() *time.Location {
+	location, err := time.LoadLocation(constant.Timezone)
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+
+	return location
+}
+
+// Writer writes a report row by row, the first row holds the column names
+type Writer interface {
+	WriteRow(values ...any) error
+}
+
+// StreamFunc writes a report. It runs after the handler has returned, so it must
+// not touch the request context.
+type StreamFunc func(w Writer) error
+
+type rowWriter interface {
+	Writer
+	Close() error
+}
+
+// IsFile reports whether the format is exported as a file rather than a JSON response
+func IsFile(format string) bool {
+	return format == FormatCSV || format == FormatXLSX
+}
+
+// Send streams the report to the client as an attachment named after the report
+func Send(ctx *fiber.Ctx, format string, name string, stream StreamFunc) error {
+	contentType := "text/csv; charset=utf-8"
+	if format == FormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	ctx.Set(fiber.HeaderContentType, contentType)
+	ctx.Attachment(fmt.Sprintf("%s-%s.%s", name, time.Now().In(Location).Format("20060102-150405"), format))
+	ctx.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer := newRowWriter(format, w, name)
+
+		// Headers are already sent, so a failure can only be logged and ends the file early
+		if err := stream(writer); err != nil {
+			log.Printf("[export] %s: %v\n", name, err)
+		}
+
+		if err := writer.Close(); err != nil {
+			log.Printf("[export] %s: %v\n", name, err)
+		}
+	})
+
+	return nil
+}
+
+func newRowWriter(format string, w io.Writer, sheet string) rowWriter {
+	if format == FormatXLSX {
+		return newXLSXWriter(w, sheet)
+	}
+
+	return newCSVWriter(w)
+}
+
+// Value formats a cell the same way in every format. Numbers are kept for the
+// XLSX writer to store as numeric cells.
+func Value(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.In(Location).Format("2006-01-02 15:04:05")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return Value(*v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return Value(*v)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isNumber(value any) bool {
+	switch v := value.(type) {
+	case int, int64, float64:
+		return true
+	case *float64:
+		return v != nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+)
+
+// Negotiate picks the supported locale with the highest quality from an Accept-Language header
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		locale  string
+		quality float64
+	}
+
+	candidates := []candidate{}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !slices.Contains(constant.Locales, locale) {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		if quality > 0 {
+			candidates = append(candidates, candidate{locale, quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return constant.DefaultLocale
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].locale
+}
+
+// Locale returns the locale negotiated for the request
+func Locale(ctx *fiber.Ctx) string {
+	locale, ok := ctx.Locals("locale").(string)
+	if !ok || locale == "" {
+		return constant.DefaultLocale
+	}
+
+	return locale
+}
+
+// Translate returns the message in the given locale, or the message itself when it has no translation
+func Translate(locale string, message string) string {
+	if translated, ok := messages[locale][message]; ok {
+		return translated
+	}
+
+	return message
+}
+
+// Message translates a message to the locale negotiated for the request
+func Message(ctx *fiber.Ctx, message string) string {
+	return Translate(Locale(ctx), message)
+}
@@ -0,0 +1,46 @@
+package i18n
+
+import "github.com/jevvonn/sea-catering-be/internal/constant"
+
+// messages maps English API messages to their translations, English is the source language
+var messages = map[string]map[string]string{
+	constant.LocaleIndonesian: {
+		// Common
+		"Invalid Request":   "Permintaan tidak valid",
+		"validation errors": "Data yang dikirim tidak valid",
+
+		// Auth
+		"User Logged In Successfully":       "Berhasil masuk",
+		"User Registered Successfully":      "Pendaftaran berhasil",
+		"email already exists":              "email sudah terdaftar",
+		"email or password is incorrect":    "email atau kata sandi salah",
+		"your account has been deactivated": "akun Anda telah dinonaktifkan",
+
+		// Plans and menus
+		"Plans retrieved successfully":             "Paket berhasil dimuat",
+		"Failed to get weekly menu":                "Gagal memuat menu mingguan",
+		"Weekly menu retrieved successfully":       "Menu mingguan berhasil dimuat",
+		"Failed to get allergens":                  "Gagal memuat daftar alergen",
+		"Allergens retrieved successfully":         "Daftar alergen berhasil dimuat",
+		"plan not found":                           "paket tidak ditemukan",
+		"invalid week format, expected dd-mm-yyyy": "format minggu tidak valid, gunakan dd-mm-yyyy",
+
+		// Testimonials
+		"Testimonials Found":                                            "Testimoni ditemukan",
+		"Testimonials Created Successfully":                             "Testimoni berhasil dikirim",
+		"Testimonial submitted and awaiting moderation":                 "Testimoni terkirim dan menunggu moderasi",
+		"Testimonial updated and awaiting moderation":                   "Testimoni diperbarui dan menunggu moderasi",
+		"Failed to get testimonials":                                    "Gagal memuat testimoni",
+		"Challenge issued":                                              "Token tantangan diterbitkan",
+		"Too many testimonials, please try again later":                 "Terlalu banyak testimoni, silakan coba lagi nanti",
+		"invalid or expired challenge token":                            "token tantangan tidak valid atau kedaluwarsa",
+		"Failed to get testimonial stats":                               "Gagal memuat statistik testimoni",
+		"Testimonial stats retrieved successfully":                      "Statistik testimoni berhasil dimuat",
+		"Failed to update testimonial":                                  "Gagal memperbarui testimoni",
+		"name is required":                                              "nama wajib diisi",
+		"sign in to review a plan":                                      "masuk terlebih dahulu untuk mengulas paket",
+		"you can only edit your own review":                             "Anda hanya dapat mengubah ulasan Anda sendiri",
+		"you have already written a review, edit it instead":            "Anda sudah menulis ulasan, silakan ubah ulasan tersebut",
+		"you have already reviewed this plan, edit your review instead": "Anda sudah mengulas paket ini, silakan ubah ulasan Anda",
+	},
+}
@@ -1,10 +1,12 @@
 package jwt
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/jevvonn/sea-catering-be/config"
 )
 
@@ -48,3 +50,71 @@ func ParseAuthToken(tokenString string) (jwt.MapClaims, error) {
 		return jwt.MapClaims{}, err
 	}
 }
+
+// challengeKey is derived from the JWT secret so challenge tokens can never pass as auth tokens
+func challengeKey() []byte {
+	config := config.Load()
+	return []byte(config.JWTSecret + ":testimonial-challenge")
+}
+
+// TestimonialChallenge holds the claims of a verified challenge token
+type TestimonialChallenge struct {
+	ID        uuid.UUID
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// CreateTestimonialChallenge issues a short lived token bound to the client IP
+// that anonymous testimonial submissions have to send back
+func CreateTestimonialChallenge(ip string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	data := jwt.MapClaims{
+		"jti": uuid.NewString(),
+		"ip":  ip,
+		"iat": now.Unix(),
+		"exp": expiresAt.Unix(),
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, data)
+	tokenString, err := t.SignedString(challengeKey())
+
+	return tokenString, expiresAt, err
+}
+
+// ParseTestimonialChallenge verifies a challenge token for the client IP and returns its claims
+func ParseTestimonialChallenge(tokenString string, ip string) (TestimonialChallenge, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return challengeKey(), nil
+	})
+	if err != nil {
+		return TestimonialChallenge{}, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["ip"] != ip {
+		return TestimonialChallenge{}, errors.New("challenge token was issued to another client")
+	}
+
+	jti, _ := claims["jti"].(string)
+	id, err := uuid.Parse(jti)
+	if err != nil {
+		return TestimonialChallenge{}, errors.New("challenge token has no id")
+	}
+
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return TestimonialChallenge{}, errors.New("challenge token has no issue time")
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return TestimonialChallenge{}, errors.New("challenge token has no expiry")
+	}
+
+	return TestimonialChallenge{ID: id, IssuedAt: issuedAt.Time, ExpiresAt: expiresAt.Time}, nil
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"strings"
+
+	"github.com/jevvonn/sea-catering-be/config"
+)
+
+type MailerService interface {
+	Send(to string, subject string, body string) error
+}
+
+type Mailer struct {
+	conf config.Config
+}
+
+func NewMailer() MailerService {
+	return &Mailer{config.Load()}
+}
+
+func (m *Mailer) Send(to string, subject string, body string) error {
+	if m.conf.SMTPHost == "" {
+		// The body can hold verification codes, so it stays out of the logs
+		log.Printf("[mailer] SMTP is not configured, dropped email to %s: %s\n", to, subject)
+		return nil
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.conf.SMTPFrom,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	addr := fmt.Sprintf("%s:%s", m.conf.SMTPHost, m.conf.SMTPPort)
+
+	var auth smtp.Auth
+	if m.conf.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", m.conf.SMTPUsername, m.conf.SMTPPassword, m.conf.SMTPHost)
+	}
+
+	return smtp.SendMail(addr, auth, m.conf.SMTPFrom, []string{to}, []byte(message))
+}
@@ -2,23 +2,80 @@ package postgresql
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/google/uuid"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
 	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
+	utils "github.com/jevvonn/sea-catering-be/internal/lib"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func Migrate(db *gorm.DB, command string) {
 	migrator := db.Migrator()
 	tables := []any{
 		&entity.User{},
+		&entity.Organization{},
+		&entity.OrganizationMember{},
 		&entity.Testimonial{},
+		&entity.TestimonialChallenge{},
 		&entity.Plans{},
+		&entity.PlanFeature{},
+		&entity.PlanTag{},
+		&entity.PlanTranslation{},
+		&entity.Allergen{},
 		&entity.Subscription{},
+		&entity.SubscriptionEvent{},
+		&entity.SubscriptionCancellation{},
+		&entity.Invoice{},
+		&entity.InvoiceLine{},
+		&entity.Dish{},
+		&entity.MenuItem{},
+		&entity.MealSelection{},
+		&entity.LoginAttempt{},
+		&entity.AuditLog{},
+		&entity.Permission{},
+		&entity.RolePermission{},
 	}
 
 	var err error
 	if command == "up" {
+		// Testimonials posted before moderation existed were already public
+		approveTestimonials := migrator.HasTable(&entity.Testimonial{}) && !migrator.HasColumn(&entity.Testimonial{}, "status")
+		backfillSubscriptionEvents := !migrator.HasTable(&entity.SubscriptionEvent{})
+
 		err = migrator.AutoMigrate(tables...)
+		if err == nil {
+			err = migratePlanFeatures(db)
+		}
+		if err == nil {
+			err = migrateSubscriptionAllergies(db)
+		}
+		if err == nil {
+			err = migrateDishAllergens(db)
+		}
+		if err == nil {
+			err = migrateMenuItemOptions(db)
+		}
+		if err == nil && approveTestimonials {
+			err = db.Model(&entity.Testimonial{}).Where("1 = 1").Update("status", constant.TestimonialStatusApproved).Error
+		}
+		if err == nil && backfillSubscriptionEvents {
+			err = migrateSubscriptionEvents(db)
+		}
+		if err == nil {
+			err = migrateRetentionDiscountEvents(db)
+		}
+		if err == nil {
+			err = migrateSearchIndexes(db)
+		}
+		if err == nil {
+			err = migrateSubscriptionUserConstraint(db)
+		}
+		if err == nil {
+			err = seedPermissions(db)
+		}
 	}
 
 	if command == "down" {
@@ -31,3 +88,246 @@ func Migrate(db *gorm.DB, command string) {
 
 	fmt.Printf("Migration %s completed successfully\n", command)
 }
+
+// seedPermissions creates missing permissions and grants them to their default
+// roles, leaving assignments of already existing permissions untouched
+func seedPermissions(db *gorm.DB) error {
+	for id, description := range constant.Permissions {
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.Permission{
+			ID:          id,
+			Description: description,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		for role, permissions := range constant.DefaultRolePermissions {
+			for _, permission := range permissions {
+				if permission != id {
+					continue
+				}
+
+				err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.RolePermission{
+					Role:         role,
+					PermissionID: id,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// migratePlanFeatures moves the old comma separated plans.features column into plan_features rows
+func migratePlanFeatures(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("plans", "features") {
+		return nil
+	}
+
+	var plans []struct {
+		ID       string
+		Features string
+	}
+	if err := db.Table("plans").Select("id, features").Scan(&plans).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, plan := range plans {
+			position := 0
+			for _, text := range strings.Split(plan.Features, ",") {
+				text = strings.TrimSpace(text)
+				if text == "" {
+					continue
+				}
+
+				position++
+				err := tx.Create(&entity.PlanFeature{
+					ID:       uuid.New(),
+					PlanId:   plan.ID,
+					Position: position,
+					Text:     text,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Migrator().DropColumn("plans", "features")
+	})
+}
+
+// migrateSubscriptionAllergies keeps the old free-text subscriptions.allergies
+// column as the allergy note, since it cannot be mapped to the catalogue reliably
+func migrateSubscriptionAllergies(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("subscriptions", "allergies") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("UPDATE subscriptions SET allergy_note = replace(allergies, ',', ', ') WHERE allergies <> ''").Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn("subscriptions", "allergies")
+	})
+}
+
+// migrateDishAllergens moves the old comma separated dishes.allergens column
+// into the allergen catalogue, creating a catalogue entry for every new name
+func migrateDishAllergens(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("dishes", "allergens") {
+		return nil
+	}
+
+	var dishes []struct {
+		ID        uuid.UUID
+		Allergens string
+	}
+	if err := db.Table("dishes").Select("id, allergens").Scan(&dishes).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, dish := range dishes {
+			for _, name := range strings.Split(dish.Allergens, ",") {
+				name = strings.TrimSpace(name)
+				code := utils.Slugify(name)
+				if code == "" {
+					continue
+				}
+
+				allergen := entity.Allergen{ID: uuid.New(), Code: code, Name: name}
+				err := tx.Where(entity.Allergen{Code: code}).Attrs(allergen).FirstOrCreate(&allergen).Error
+				if err != nil {
+					return err
+				}
+
+				err = tx.Exec(
+					"INSERT INTO dish_allergens (dish_id, allergen_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+					dish.ID, allergen.ID,
+				).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Migrator().DropColumn("dishes", "allergens")
+	})
+}
+
+// migrateMenuItemOptions drops the old one dish per meal index. The dishes
+// scheduled back then become the default of their meal.
+func migrateMenuItemOptions(db *gorm.DB) error {
+	if !db.Migrator().HasIndex(&entity.MenuItem{}, "idx_menu_item_slot") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropIndex(&entity.MenuItem{}, "idx_menu_item_slot"); err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.MenuItem{}).Where("1 = 1").Update("is_default", true).Error
+	})
+}
+
+// migrateSubscriptionEvents builds the status history of subscriptions created before it
+// was recorded. The last update of a cancelled subscription is the best guess of when it
+// was cancelled.
+func migrateSubscriptionEvents(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(
+			"UPDATE subscriptions SET cancelled_at = updated_at WHERE status = ? AND cancelled_at IS NULL",
+			constant.SubscriptionStatusCancelled,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(
+			`INSERT INTO subscription_events (id, subscription_id, type, mrr, occurred_at, created_at)
+			SELECT gen_random_uuid(), id, ?, total_price, created_at, NOW() FROM subscriptions`,
+			constant.SubscriptionEventCreated,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			`INSERT INTO subscription_events (id, subscription_id, type, mrr, occurred_at, created_at)
+			SELECT gen_random_uuid(), id, ?, total_price, cancelled_at, NOW() FROM subscriptions WHERE status = ?`,
+			constant.SubscriptionEventCancelled,
+			constant.SubscriptionStatusCancelled,
+		).Error
+	})
+}
+
+// migrateRetentionDiscountEvents records the MRR change of retention discounts accepted
+// before discounts were part of the status history
+func migrateRetentionDiscountEvents(db *gorm.DB) error {
+	return db.Exec(
+		`INSERT INTO subscription_events (id, subscription_id, type, mrr, occurred_at, created_at)
+		SELECT gen_random_uuid(), s.id, @changed, ROUND(s.total_price * (100 - s.discount_percent) / 100, 2), s.retention_offer_at, NOW()
+		FROM subscriptions s
+		WHERE s.discount_percent > 0 AND s.retention_offer_at IS NOT NULL AND NOT EXISTS (
+			SELECT 1 FROM subscription_events e WHERE e.subscription_id = s.id AND e.type = @changed
+		)`,
+		map[string]any{"changed": constant.SubscriptionEventMRRChanged},
+	).Error
+}
+
+// migrateSubscriptionUserConstraint replaces the ON DELETE CASCADE user foreign key of
+// subscriptions with the RESTRICT one of the entity. AutoMigrate only creates missing
+// constraints, so it never changes the delete rule of an existing one.
+func migrateSubscriptionUserConstraint(db *gorm.DB) error {
+	var outdated int64
+	err := db.Raw(
+		`SELECT COUNT(*) FROM information_schema.referential_constraints
+		WHERE constraint_name = 'fk_subscriptions_user' AND delete_rule <> 'RESTRICT'`,
+	).Scan(&outdated).Error
+	if err != nil || outdated == 0 {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropConstraint(&entity.Subscription{}, "User"); err != nil {
+			return err
+		}
+
+		return tx.Migrator().CreateConstraint(&entity.Subscription{}, "User")
+	})
+}
+
+// searchIndexes back the support search with trigram indexes. Phone numbers are indexed
+// by their digits only, the search query has to use the same expression.
+var searchIndexes = map[string]string{
+	"idx_subscriptions_name_trgm":         "subscriptions USING gin (name gin_trgm_ops)",
+	"idx_subscriptions_phone_digits_trgm": "subscriptions USING gin ((regexp_replace(phone_number, '[^0-9]', '', 'g')) gin_trgm_ops)",
+	"idx_users_name_trgm":                 "users USING gin (name gin_trgm_ops)",
+	"idx_users_email_trgm":                "users USING gin (email gin_trgm_ops)",
+}
+
+func migrateSearchIndexes(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return err
+	}
+
+	for name, definition := range searchIndexes {
+		if err := db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s", name, definition)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
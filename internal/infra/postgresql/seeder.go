@@ -37,7 +37,18 @@ func Seed(db *gorm.DB) {
 		Name:     "Diet Plan",
 		Slogan:   "Light & Nutritious",
 		Price:    30000,
-		Features: "300-400 calories per meal, High fiber content, Low fat recipes, Portion controlled, Fresh vegetables daily",
+		Features: planFeatures("diet", "300-400 calories per meal", "High fiber content", "Low fat recipes", "Portion controlled", "Fresh vegetables daily"),
+		Tags:     planTags("diet", constant.PlanTagHalal, constant.PlanTagGlutenFree),
+		Nutrition: entity.PlanNutrition{
+			CaloriesMin:  300,
+			CaloriesMax:  400,
+			ProteinGrams: 20,
+			CarbsGrams:   40,
+			FatGrams:     10,
+		},
+
+		DisplayOrder: 1,
+		IsAvailable:  true,
 	}
 
 	proteinPlan := entity.Plans{
@@ -45,7 +56,18 @@ func Seed(db *gorm.DB) {
 		Name:     "Protein Plan",
 		Slogan:   "Power & Performance",
 		Price:    40000,
-		Features: "25-35g protein per meal, Lean meat & fish, Post-workout friendly, Balanced macronutrients, Athletic performance focused",
+		Features: planFeatures("protein", "25-35g protein per meal", "Lean meat & fish", "Post-workout friendly", "Balanced macronutrients", "Athletic performance focused"),
+		Tags:     planTags("protein", constant.PlanTagHalal),
+		Nutrition: entity.PlanNutrition{
+			CaloriesMin:  500,
+			CaloriesMax:  650,
+			ProteinGrams: 30,
+			CarbsGrams:   50,
+			FatGrams:     18,
+		},
+
+		DisplayOrder: 2,
+		IsAvailable:  true,
 	}
 
 	royalPlan := entity.Plans{
@@ -53,7 +75,18 @@ func Seed(db *gorm.DB) {
 		Name:     "Royal Plan",
 		Slogan:   "Luxury & Elegance",
 		Price:    60000,
-		Features: "Premium ingredients, Chef-crafted recipes, Restaurant quality, Exclusive menu items",
+		Features: planFeatures("royal", "Premium ingredients", "Chef-crafted recipes", "Restaurant quality", "Exclusive menu items"),
+		Tags:     planTags("royal", constant.PlanTagHalal),
+		Nutrition: entity.PlanNutrition{
+			CaloriesMin:  600,
+			CaloriesMax:  800,
+			ProteinGrams: 35,
+			CarbsGrams:   60,
+			FatGrams:     25,
+		},
+
+		DisplayOrder: 3,
+		IsAvailable:  true,
 	}
 
 	err = db.Create(&dietPlan).Error
@@ -71,6 +104,11 @@ func Seed(db *gorm.DB) {
 		panic(err)
 	}
 
+	err = db.Create(seedAllergens("Peanut", "Tree Nuts", "Milk", "Egg", "Fish", "Shellfish", "Soy", "Wheat", "Sesame")).Error
+	if err != nil {
+		panic(err)
+	}
+
 	err = db.Create(&adminAccount).Error
 	if err != nil {
 		panic(err)
@@ -83,3 +121,39 @@ func Seed(db *gorm.DB) {
 
 	fmt.Println("Database seeded successfully with initial data.")
 }
+
+func planFeatures(planId string, features ...string) []entity.PlanFeature {
+	result := []entity.PlanFeature{}
+	for i, text := range features {
+		result = append(result, entity.PlanFeature{
+			ID:       uuid.New(),
+			PlanId:   planId,
+			Position: i + 1,
+			Text:     text,
+		})
+	}
+
+	return result
+}
+
+func planTags(planId string, tags ...string) []entity.PlanTag {
+	result := []entity.PlanTag{}
+	for _, tag := range tags {
+		result = append(result, entity.PlanTag{PlanId: planId, Tag: tag})
+	}
+
+	return result
+}
+
+func seedAllergens(names ...string) []entity.Allergen {
+	result := []entity.Allergen{}
+	for _, name := range names {
+		result = append(result, entity.Allergen{
+			ID:   uuid.New(),
+			Code: utils.Slugify(name),
+			Name: name,
+		})
+	}
+
+	return result
+}
@@ -2,46 +2,61 @@ package validator
 
 import (
 	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/infra/i18n"
 )
 
 type ValidationService interface {
 	Validate(req any) error
+	// ValidateLocale validates like Validate with error messages in the given locale
+	ValidateLocale(req any, locale string) error
 }
 
 type Validator struct {
-	vd         *validator.Validate
-	translator ut.Translator
+	vd  *validator.Validate
+	uni *ut.UniversalTranslator
 }
 
 func NewValidator() ValidationService {
 	vd := validator.New()
 	en := en.New()
-	uni := ut.New(en, en)
-	trans, _ := uni.GetTranslator("en")
-	en_translations.RegisterDefaultTranslations(vd, trans)
+	uni := ut.New(en, en, id.New())
+
+	enTrans, _ := uni.GetTranslator(constant.LocaleEnglish)
+	en_translations.RegisterDefaultTranslations(vd, enTrans)
+
+	idTrans, _ := uni.GetTranslator(constant.LocaleIndonesian)
+	id_translations.RegisterDefaultTranslations(vd, idTrans)
 
 	return &Validator{
-		vd, trans,
+		vd, uni,
 	}
 }
 
 func (v *Validator) Validate(req any) error {
+	return v.ValidateLocale(req, constant.DefaultLocale)
+}
+
+func (v *Validator) ValidateLocale(req any, locale string) error {
 	err := v.vd.Struct(req)
 	if err != nil {
+		translator, _ := v.uni.GetTranslator(locale)
 		errorMap := []ErrorField{}
 
 		for _, e := range err.(validator.ValidationErrors) {
-			message := e.Translate(v.translator)
+			message := e.Translate(translator)
 			errorMap = append(errorMap, ErrorField{
 				Field:   e.Field(),
 				Message: message,
 			})
 		}
 
-		return NewValidationErr(errorMap, "validation errors")
+		return NewValidationErr(errorMap, i18n.Translate(locale, "validation errors"))
 	}
 
 	return nil
@@ -1,6 +1,25 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const randomStringCharset = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// MonthLayout is the mm-yyyy format months are read and written in by the reports
+const MonthLayout = "01-2006"
+
+// maxReportMonths keeps a single report query bounded
+const maxReportMonths = 36
 
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
@@ -11,3 +30,71 @@ func VerifyPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// GenerateRandomString returns a cryptographically random string without look-alike characters
+func GenerateRandomString(length int) (string, error) {
+	result := make([]byte, length)
+	max := big.NewInt(int64(len(randomStringCharset)))
+
+	for i := range result {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		result[i] = randomStringCharset[n.Int64()]
+	}
+
+	return string(result), nil
+}
+
+// HashToken hashes a random token so it can be stored and looked up without keeping the raw value
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// StartOfWeek returns the Monday of the week containing t as a UTC calendar date
+func StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// Slugify lowercases s and joins its words with dashes
+func Slugify(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// ParseMonthRange parses mm-yyyy months, defaulting to the 12 months up to the current one
+func ParseMonthRange(start string, end string) (time.Time, time.Time, error) {
+	now := time.Now()
+	endMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if end != "" {
+		parsed, err := time.Parse(MonthLayout, end)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid end month format, expected mm-yyyy")
+		}
+		endMonth = parsed
+	}
+
+	startMonth := endMonth.AddDate(0, -11, 0)
+	if start != "" {
+		parsed, err := time.Parse(MonthLayout, start)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid start month format, expected mm-yyyy")
+		}
+		startMonth = parsed
+	}
+
+	if startMonth.After(endMonth) {
+		return time.Time{}, time.Time{}, errors.New("start month cannot be after end month")
+	}
+
+	if startMonth.AddDate(0, maxReportMonths, 0).Before(endMonth.AddDate(0, 1, 0)) {
+		return time.Time{}, time.Time{}, errors.New("reports cover at most 36 months")
+	}
+
+	return startMonth, endMonth, nil
+}
@@ -0,0 +1,27 @@
+package utils
+
+import "testing"
+
+func TestParseMonthRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{"single month", "03-2025", "03-2025", false},
+		{"36 months", "01-2023", "12-2025", false},
+		{"37 months", "12-2022", "12-2025", true},
+		{"start after end", "04-2025", "03-2025", true},
+		{"wrong format", "2025-03", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseMonthRange(tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,14 +1,32 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/domain/entity"
 	"github.com/jevvonn/sea-catering-be/internal/infra/jwt"
+	"gorm.io/gorm"
 )
 
-func Authenticated(ctx *fiber.Ctx) error {
+type UserStatusChecker interface {
+	GetAuthenticatedUser(userId string) (entity.User, error)
+}
+
+// OptionalAuthenticated lets guests through and authenticates requests that carry a token
+func (m *Middleware) OptionalAuthenticated(ctx *fiber.Ctx) error {
+	if ctx.Get("Authorization") == "" {
+		return ctx.Next()
+	}
+
+	return m.Authenticated(ctx)
+}
+
+// Authenticated rejects requests without a valid token of an active user
+func (m *Middleware) Authenticated(ctx *fiber.Ctx) error {
 	headers := ctx.Get("Authorization")
 
 	if headers == "" {
@@ -40,5 +58,30 @@ func Authenticated(ctx *fiber.Ctx) error {
 	ctx.Locals("email", claims["email"])
 	ctx.Locals("role", claims["role"])
 
+	userId, _ := claims["sub"].(string)
+
+	user, err := m.userStatusChecker.GetAuthenticatedUser(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+	if err != nil {
+		log.Printf("[auth] failed to load user %s: %v\n", userId, err)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"message": "Failed to authenticate",
+		})
+	}
+
+	if !user.IsActive {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"message": "Account is deactivated",
+		})
+	}
+
+	// The role in the token is stale once an admin changes it
+	ctx.Locals("email", user.Email)
+	ctx.Locals("role", user.Role)
+
 	return ctx.Next()
 }
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jevvonn/sea-catering-be/internal/constant"
+	"github.com/jevvonn/sea-catering-be/internal/infra/i18n"
+)
+
+// Locale negotiates the response language from the lang query parameter or the
+// Accept-Language header and stores it in the request locals
+func Locale(ctx *fiber.Ctx) error {
+	locale := ctx.Query("lang")
+	if !slices.Contains(constant.Locales, locale) {
+		locale = i18n.Negotiate(ctx.Get(fiber.HeaderAcceptLanguage))
+	}
+
+	ctx.Locals("locale", locale)
+	ctx.Set(fiber.HeaderContentLanguage, locale)
+	ctx.Vary(fiber.HeaderAcceptLanguage)
+
+	return ctx.Next()
+}
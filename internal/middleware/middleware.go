@@ -0,0 +1,13 @@
+package middleware
+
+// Middleware holds the dependencies of the middlewares that need to look up the current user
+type Middleware struct {
+	userStatusChecker UserStatusChecker
+	permissionChecker PermissionChecker
+}
+
+// New returns the middlewares that reject deactivated users and take the current role and
+// permissions from the given checkers
+func New(userStatusChecker UserStatusChecker, permissionChecker PermissionChecker) *Middleware {
+	return &Middleware{userStatusChecker, permissionChecker}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PermissionChecker interface {
+	HasPermission(role string, permission string) (bool, error)
+}
+
+// RequirePermission only lets the request through when the user's role has every given permission
+func (m *Middleware) RequirePermission(permissions ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		role := ctx.Locals("role").(string)
+
+		for _, permission := range permissions {
+			allowed, err := m.permissionChecker.HasPermission(role, permission)
+			if err != nil {
+				log.Printf("[permission] failed to check %s for role %s: %v\n", permission, role, err)
+				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"message": "Failed to check permissions",
+				})
+			}
+
+			if !allowed {
+				return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"message": "Forbidden resource",
+				})
+			}
+		}
+
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type fakePermissionChecker struct {
+	permissions map[string][]string
+	err         error
+}
+
+func (c fakePermissionChecker) HasPermission(role string, permission string) (bool, error) {
+	if c.err != nil {
+		return false, c.err
+	}
+
+	for _, p := range c.permissions[role] {
+		if p == permission {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func newPermissionTestApp(checker PermissionChecker, role string, permissions ...string) *fiber.App {
+	mw := New(nil, checker)
+
+	app := fiber.New()
+	app.Get("/",
+		func(ctx *fiber.Ctx) error {
+			ctx.Locals("role", role)
+			return ctx.Next()
+		},
+		mw.RequirePermission(permissions...),
+		func(ctx *fiber.Ctx) error {
+			return ctx.SendString("ok")
+		},
+	)
+
+	return app
+}
+
+func TestRequirePermission(t *testing.T) {
+	checker := fakePermissionChecker{permissions: map[string][]string{
+		"ADMIN": {"plans:write", "reports:read"},
+		"USER":  {"subscriptions:read"},
+	}}
+
+	tests := []struct {
+		name        string
+		role        string
+		permissions []string
+		status      int
+	}{
+		{"granted", "ADMIN", []string{"plans:write"}, fiber.StatusOK},
+		{"every permission granted", "ADMIN", []string{"plans:write", "reports:read"}, fiber.StatusOK},
+		{"one permission missing", "ADMIN", []string{"plans:write", "users:write"}, fiber.StatusForbidden},
+		{"not granted", "USER", []string{"plans:write"}, fiber.StatusForbidden},
+		{"unknown role", "GUEST", []string{"subscriptions:read"}, fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := newPermissionTestApp(checker, tt.role, tt.permissions...).Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestRequirePermissionHidesCheckerErrors(t *testing.T) {
+	checker := fakePermissionChecker{err: errors.New(`pq: relation "role_permissions" does not exist`)}
+
+	resp, err := newPermissionTestApp(checker, "ADMIN", "plans:write").Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusInternalServerError)
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body["message"] != "Failed to check permissions" {
+		t.Fatalf("message = %q, want the generic message", body["message"])
+	}
+}